@@ -0,0 +1,72 @@
+package pdqselect
+
+import (
+	"cmp"
+	"math/bits"
+	"sort"
+)
+
+// ArgSelect is like Select, but it leaves the data untouched and instead
+// permutes a slice of indices into it, so that the first k indices point at the
+// smallest k elements in the data. It's the selection counterpart of NumPy's
+// argpartition, for data that can't be reordered.
+//
+// The indices are written into idx if it has capacity for n of them, and into
+// a newly allocated slice otherwise. Either way, the slice holding the n
+// indices is returned. If k is out of range, the indices are returned in order.
+func ArgSelect(data sort.Interface, k int, idx []int) []int {
+	n := data.Len()
+	idx = identity(idx, n)
+	if k < 1 || k > n {
+		return idx
+	}
+	pdqselect(argInterface{data, idx}, 0, n, k-1, bits.Len(uint(n)))
+	return idx
+}
+
+// ArgOrdered is a specialized version of ArgSelect that works with slices of
+// ordered types (i.e. types that implement the cmp.Ordered interface).
+func ArgOrdered[T cmp.Ordered](data []T, k int, idx []int) []int {
+	n := len(data)
+	idx = identity(idx, n)
+	if k < 1 || k > n {
+		return idx
+	}
+	pdqselectArgOrdered(idx, 0, n, k-1, bits.Len(uint(n)), data)
+	return idx
+}
+
+// ArgFunc is a generic version of ArgSelect that allows the caller to provide
+// a custom comparison function to determine the order of elements.
+func ArgFunc[E any](data []E, k int, idx []int, cmp func(a, b E) int) []int {
+	n := len(data)
+	idx = identity(idx, n)
+	if k < 1 || k > n {
+		return idx
+	}
+	pdqselectArgCmpFunc(idx, 0, n, k-1, bits.Len(uint(n)), data, cmp)
+	return idx
+}
+
+// identity returns idx, or a new slice if it's too small, holding 0, 1, ..., n-1.
+func identity(idx []int, n int) []int {
+	if cap(idx) < n {
+		idx = make([]int, n)
+	}
+	idx = idx[:n]
+	for i := range idx {
+		idx[i] = i
+	}
+	return idx
+}
+
+// argInterface compares the elements of data the indices in idx point at
+// and swaps the indices.
+type argInterface struct {
+	data sort.Interface
+	idx  []int
+}
+
+func (x argInterface) Len() int           { return len(x.idx) }
+func (x argInterface) Less(i, j int) bool { return x.data.Less(x.idx[i], x.idx[j]) }
+func (x argInterface) Swap(i, j int)      { x.idx[i], x.idx[j] = x.idx[j], x.idx[i] }
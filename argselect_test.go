@@ -0,0 +1,82 @@
+package pdqselect
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestArgSelect(t *testing.T) {
+	for _, c := range selectCasesOutOfRange() {
+		t.Run("ArgSelect/"+c.name, func(t *testing.T) {
+			testArgSelect(t, c.input, c.k, func(data []int, idx []int) []int {
+				return ArgSelect(sort.IntSlice(data), c.k, idx)
+			})
+		})
+
+		t.Run("ArgOrdered/"+c.name, func(t *testing.T) {
+			testArgSelect(t, c.input, c.k, func(data []int, idx []int) []int {
+				return ArgOrdered(data, c.k, idx)
+			})
+		})
+
+		t.Run("ArgFunc/"+c.name, func(t *testing.T) {
+			testArgSelect(t, c.input, c.k, func(data []int, idx []int) []int {
+				return ArgFunc(data, c.k, idx, cmp.Compare)
+			})
+		})
+	}
+}
+
+func TestArgSelectReusesIndices(t *testing.T) {
+	data := []int{5, 4, 0, 10, 1, 2, 1}
+
+	idx := make([]int, 3, len(data))
+	got := ArgOrdered(data, 3, idx)
+	if &got[0] != &idx[0] {
+		t.Errorf("ArgOrdered allocated a new index slice despite sufficient capacity")
+	}
+
+	got = ArgOrdered(data, 3, make([]int, 0, 2))
+	if len(got) != len(data) {
+		t.Errorf("ArgOrdered returned %d indices, want %d", len(got), len(data))
+	}
+}
+
+func testArgSelect(t *testing.T, input []int, k int, argSelect func([]int, []int) []int) {
+	t.Helper()
+
+	data := slices.Clone(input)
+	idx := argSelect(data, nil)
+
+	if !slices.Equal(data, input) {
+		t.Fatalf("k=%d: data was modified\ninput: %v\ndata:  %v", k, input, data)
+	}
+
+	sortedIdx := slices.Clone(idx)
+	slices.Sort(sortedIdx)
+	for i, j := range sortedIdx {
+		if i != j {
+			t.Fatalf("k=%d: indices aren't a permutation of 0..n-1: %v", k, idx)
+		}
+	}
+
+	if k < 1 || k > len(input) {
+		return
+	}
+
+	sorted := slices.Clone(input)
+	slices.Sort(sorted)
+	kth := data[idx[k-1]]
+
+	if kth != sorted[k-1] {
+		t.Fatalf("k=%d: k-th element (%d) does not match sorted input (%d)", k, kth, sorted[k-1])
+	}
+
+	for i, j := range idx {
+		if (i < k && data[j] > kth) || (i >= k && data[j] < kth) {
+			t.Fatalf("k=%d: element at index %d of the indices (%d) isn't partitioned around %d", k, i, data[j], kth)
+		}
+	}
+}
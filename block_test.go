@@ -0,0 +1,112 @@
+package pdqselect
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+var distributions = []string{"random", "sorted", "reversed", "mostly_sorted", "organ_pipe", "sawtooth", "push_front", "push_middle", "zipf"}
+
+func TestPartitionBlockOrdered(t *testing.T) {
+	now := time.Now().UnixNano()
+	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
+
+	for _, dist := range distributions {
+		for _, size := range []int{2, 10, blockThreshold - 1, blockThreshold, 2*blockSize + 1, 1000, 10000} {
+			input := generateSlice(rng, size, dist)
+
+			for _, pivot := range []int{0, 1, size / 2, size - 1} {
+				name := fmt.Sprintf("%s/n=%d/pivot=%d", dist, size, pivot)
+
+				t.Run(name, func(t *testing.T) {
+					data := slices.Clone(input)
+					p := data[pivot]
+					mid, alreadyPartitioned := partitionBlockOrdered(data, 0, size, pivot)
+
+					if data[mid] != p {
+						t.Fatalf("pivot %d ended up at %d, which holds %d", p, mid, data[mid])
+					}
+					for i, x := range data {
+						if (i < mid && x >= p) || (i > mid && x < p) {
+							t.Fatalf("element at index %d (%d) isn't partitioned around %d at %d", i, x, p, mid)
+						}
+					}
+
+					_, want := partitionOrdered(slices.Clone(input), 0, size, pivot)
+					if alreadyPartitioned != want {
+						t.Fatalf("got alreadyPartitioned=%t, want %t", alreadyPartitioned, want)
+					}
+
+					sorted, got := slices.Clone(input), slices.Clone(data)
+					slices.Sort(sorted)
+					slices.Sort(got)
+					if !slices.Equal(got, sorted) {
+						t.Fatalf("elements aren't a permutation of the input")
+					}
+				})
+			}
+		}
+	}
+}
+
+func BenchmarkPartitionBlockOrdered(b *testing.B) {
+	rng := rand.New(rand.NewPCG(42, 42)) // Static seed for consistent benchmarks
+
+	for _, n := range []int{1e4, 1e6} {
+		for _, dist := range distributions {
+			// Cycle through different inputs of the same distribution so that the
+			// branch predictor can't learn the outcomes of partitioning small ones.
+			inputs := make([][]int, max(1, 1e6/n))
+			for i := range inputs {
+				inputs[i] = generateSlice(rng, n, dist)
+			}
+			dataCopy := make([]int, n)
+			pivot := n / 2
+			benchName := fmt.Sprintf("n=%d/%s", n, dist)
+
+			b.Run("fn=partitionOrdered/"+benchName, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					copy(dataCopy, inputs[i%len(inputs)])
+					partitionOrdered(dataCopy, 0, n, pivot)
+				}
+			})
+
+			b.Run("fn=partitionBlockOrdered/"+benchName, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					copy(dataCopy, inputs[i%len(inputs)])
+					partitionBlockOrdered(dataCopy, 0, n, pivot)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkOrderedDistributions runs Ordered end to end on every distribution,
+// to weigh partitionBlockOrdered against partitionOrdered in pdqselectOrdered
+// beyond a single partition.
+func BenchmarkOrderedDistributions(b *testing.B) {
+	rng := rand.New(rand.NewPCG(42, 42)) // Static seed for consistent benchmarks
+
+	for _, n := range []int{1e4, 1e6} {
+		for _, dist := range distributions {
+			// Cycle through different inputs, as in BenchmarkPartitionBlockOrdered.
+			inputs := make([][]int, max(1, 1e6/n))
+			for i := range inputs {
+				inputs[i] = generateSlice(rng, n, dist)
+			}
+			dataCopy := make([]int, n)
+
+			for _, k := range []int{n / 100, n / 2} {
+				b.Run(fmt.Sprintf("n=%d/k=%d/%s", n, k, dist), func(b *testing.B) {
+					for i := 0; i < b.N; i++ {
+						copy(dataCopy, inputs[i%len(inputs)])
+						Ordered(dataCopy, k)
+					}
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package pdqselect
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+// ErrRankOutOfRange is the error the checked variants report when k isn't a
+// valid rank for the data. The actual error is a *RankError, which matches
+// ErrRankOutOfRange with errors.Is.
+var ErrRankOutOfRange = errors.New("pdqselect: rank out of range")
+
+// RankError reports a rank K that's out of range for data of length N.
+type RankError struct {
+	K, N int
+}
+
+func (e *RankError) Error() string {
+	return fmt.Sprintf("pdqselect: rank %d out of range [1, %d]", e.K, e.N)
+}
+
+// Is reports whether target is ErrRankOutOfRange.
+func (e *RankError) Is(target error) bool {
+	return target == ErrRankOutOfRange
+}
+
+// RankPolicy controls which ranks outside of [1, n] the checked variants accept
+// instead of reporting a *RankError. The zero RankPolicy accepts none of them.
+// Negative ranks are always rejected.
+type RankPolicy uint8
+
+const (
+	// AllowZero accepts k == 0 as an empty selection, which leaves the data untouched.
+	AllowZero RankPolicy = 1 << iota
+	// ClampHigh accepts k > n and clamps it to n, selecting all of the elements.
+	ClampHigh
+)
+
+// check returns the rank to select given k, or 0 if there's nothing to do.
+func (p RankPolicy) check(k, n int) (int, error) {
+	switch {
+	case k == 0 && p&AllowZero != 0:
+		return 0, nil
+	case k > n && k > 0 && p&ClampHigh != 0:
+		return n, nil
+	case k < 1 || k > n:
+		return 0, &RankError{K: k, N: n}
+	}
+	return k, nil
+}
+
+// SelectChecked is like Select, but it returns a *RankError instead of silently
+// doing nothing when k isn't in [1, n], unless the policy p accepts it.
+func SelectChecked(data sort.Interface, k int, p RankPolicy) error {
+	n := data.Len()
+	k, err := p.check(k, n)
+	if err != nil || k == 0 {
+		return err
+	}
+	if !selectSlice(data, n, k) {
+		pdqselect(data, 0, n, k-1, bits.Len(uint(n)))
+	}
+	return nil
+}
+
+// OrderedChecked is like Ordered, but it returns a *RankError instead of silently
+// doing nothing when k isn't in [1, n], unless the policy p accepts it.
+func OrderedChecked[T cmp.Ordered](data []T, k int, p RankPolicy) error {
+	n := len(data)
+	k, err := p.check(k, n)
+	if err != nil || k == 0 {
+		return err
+	}
+	pdqselectOrdered(data, 0, n, k-1, bits.Len(uint(n)))
+	return nil
+}
+
+// FuncChecked is like Func, but it returns a *RankError instead of silently
+// doing nothing when k isn't in [1, n], unless the policy p accepts it.
+func FuncChecked[E any](data []E, k int, p RankPolicy, cmp func(a, b E) int) error {
+	n := len(data)
+	k, err := p.check(k, n)
+	if err != nil || k == 0 {
+		return err
+	}
+	floydRivestCmpFunc(data, 0, n, k-1, cmp)
+	return nil
+}
+
+// MustSelect is like SelectChecked, but it panics with the *RankError instead of
+// returning it. It's meant for ranks that are invalid only because of a bug.
+func MustSelect(data sort.Interface, k int, p RankPolicy) {
+	if err := SelectChecked(data, k, p); err != nil {
+		panic(err)
+	}
+}
+
+// MustOrdered is like OrderedChecked, but it panics with the *RankError instead
+// of returning it.
+func MustOrdered[T cmp.Ordered](data []T, k int, p RankPolicy) {
+	if err := OrderedChecked(data, k, p); err != nil {
+		panic(err)
+	}
+}
+
+// MustFunc is like FuncChecked, but it panics with the *RankError instead of
+// returning it.
+func MustFunc[E any](data []E, k int, p RankPolicy, cmp func(a, b E) int) {
+	if err := FuncChecked(data, k, p, cmp); err != nil {
+		panic(err)
+	}
+}
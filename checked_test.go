@@ -0,0 +1,98 @@
+package pdqselect
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestChecked(t *testing.T) {
+	input := []int{5, 3, 9, 1, 7}
+
+	testCases := []struct {
+		k      int
+		policy RankPolicy
+		want   int // The rank that's selected, 0 for none
+		err    bool
+	}{
+		{1, 0, 1, false},
+		{3, 0, 3, false},
+		{5, 0, 5, false},
+		{0, 0, 0, true},
+		{-1, 0, 0, true},
+		{6, 0, 0, true},
+		{0, AllowZero, 0, false},
+		{6, AllowZero, 0, true},
+		{6, ClampHigh, 5, false},
+		{0, ClampHigh, 0, true},
+		{-1, AllowZero | ClampHigh, 0, true},
+		{0, AllowZero | ClampHigh, 0, false},
+		{100, AllowZero | ClampHigh, 5, false},
+	}
+
+	for _, tc := range testCases {
+		name := fmt.Sprintf("k=%d/policy=%d", tc.k, tc.policy)
+
+		for fn, checked := range map[string]func([]int) error{
+			"SelectChecked":          func(data []int) error { return SelectChecked(intSlice(data), tc.k, tc.policy) },
+			"SelectChecked/IntSlice": func(data []int) error { return SelectChecked(sort.IntSlice(data), tc.k, tc.policy) },
+			"OrderedChecked":         func(data []int) error { return OrderedChecked(data, tc.k, tc.policy) },
+			"FuncChecked":            func(data []int) error { return FuncChecked(data, tc.k, tc.policy, cmp.Compare) },
+		} {
+			t.Run(fn+"/"+name, func(t *testing.T) {
+				data := slices.Clone(input)
+				err := checked(data)
+
+				if !tc.err {
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+				} else {
+					var rankErr *RankError
+					if !errors.As(err, &rankErr) || !errors.Is(err, ErrRankOutOfRange) {
+						t.Fatalf("got error %v, want a *RankError matching ErrRankOutOfRange", err)
+					}
+					if rankErr.K != tc.k || rankErr.N != len(input) {
+						t.Fatalf("got RankError{K: %d, N: %d}, want RankError{K: %d, N: %d}", rankErr.K, rankErr.N, tc.k, len(input))
+					}
+				}
+
+				if tc.want == 0 {
+					if !slices.Equal(data, input) {
+						t.Fatalf("data was modified: %v", data)
+					}
+					return
+				}
+				// The data is already selected; hand it over to testSelect as is.
+				testSelect(t, input, 0, len(input), tc.want, fn, func(slice []int, a, b, k int) {
+					copy(slice, data)
+				})
+			})
+		}
+	}
+}
+
+func TestMust(t *testing.T) {
+	for fn, must := range map[string]func([]int, int){
+		"MustSelect":          func(data []int, k int) { MustSelect(intSlice(data), k, 0) },
+		"MustSelect/IntSlice": func(data []int, k int) { MustSelect(sort.IntSlice(data), k, 0) },
+		"MustOrdered":         func(data []int, k int) { MustOrdered(data, k, 0) },
+		"MustFunc":            func(data []int, k int) { MustFunc(data, k, 0, cmp.Compare) },
+	} {
+		t.Run(fn, func(t *testing.T) {
+			testSelect(t, []int{5, 3, 9, 1, 7}, 0, 5, 2, fn, func(slice []int, a, b, k int) {
+				must(slice, k)
+			})
+
+			defer func() {
+				if err, ok := recover().(error); !ok || !errors.Is(err, ErrRankOutOfRange) {
+					t.Fatalf("got panic %v, want ErrRankOutOfRange", err)
+				}
+			}()
+			must([]int{5, 3, 9, 1, 7}, 0)
+		})
+	}
+}
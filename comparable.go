@@ -0,0 +1,17 @@
+package pdqselect
+
+import "math/bits"
+
+// Comparable is a specialized version of Select that works with slices of types
+// with a Compare method, like time.Time or netip.Addr, which orders them the way
+// cmp.Compare orders ordered types. It calls the method rather than a comparison
+// function, which spares wrapping it in a closure for Func. How much faster that
+// is depends on whether the compiler can resolve the method statically; with
+// time.Time, it's about as fast as Func with time.Time.Compare.
+func Comparable[E interface{ Compare(E) int }](data []E, k int) {
+	n := len(data)
+	if k < 1 || k > n {
+		return
+	}
+	pdqselectComparable(data, 0, n, k-1, bits.Len(uint(n)))
+}
@@ -0,0 +1,65 @@
+package pdqselect
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestComparable(t *testing.T) {
+	for _, c := range selectCases() {
+		input := slices.Clone(c.input)
+		for i := range input {
+			input[i] %= 1 << 40 // Keep within the range of valid Unix times
+		}
+
+		t.Run("Comparable/"+c.name, func(t *testing.T) {
+			testSelect(t, input, 0, len(input), c.k, "Comparable", func(slice []int, a, b, k int) {
+				times := unixTimes(slice)
+				Comparable(times, k)
+				for i, t := range times {
+					slice[i] = int(t.Unix())
+				}
+			})
+		})
+	}
+}
+
+func unixTimes(data []int) []time.Time {
+	times := make([]time.Time, len(data))
+	for i, x := range data {
+		times[i] = time.Unix(int64(x), 0)
+	}
+	return times
+}
+
+func BenchmarkComparable(b *testing.B) {
+	rng := rand.New(rand.NewPCG(42, 42)) // Static seed for consistent benchmarks
+	const n = 1e5
+
+	for _, dist := range []string{"random", "sorted", "zipf"} {
+		input := generateSlice(rng, n, dist)
+		for i := range input {
+			input[i] %= 1 << 40
+		}
+		data := unixTimes(input)
+		k := int(n / 2)
+		benchName := fmt.Sprintf("n=%d/k=%d/%s", int(n), k, dist)
+
+		b.Run("fn=Func/"+benchName, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dataCopy := slices.Clone(data)
+				Func(dataCopy, k, time.Time.Compare)
+			}
+		})
+
+		b.Run("fn=Comparable/"+benchName, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dataCopy := slices.Clone(data)
+				Comparable(dataCopy, k)
+			}
+		})
+	}
+}
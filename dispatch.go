@@ -0,0 +1,91 @@
+package pdqselect
+
+import (
+	"cmp"
+	"math/bits"
+	"sort"
+)
+
+// OrderedSlicer is an optional interface for sort.Interface implementations
+// backed by a slice of one of Go's predeclared ordered types, whose Less and
+// Swap are those of the slice, like sort.IntSlice. Select then selects on the
+// slice with the same fast path as Ordered instead of calling Less and Swap.
+type OrderedSlicer interface {
+	// OrderedSlice returns the slice backing the data: a []int, []int8, ...,
+	// []uint, []uint8, ..., []uintptr, []float32, []float64 or []string of
+	// length Len(). As with sort.Float64Slice, NaNs are ordered first.
+	OrderedSlice() any
+}
+
+// selectSlice selects the k-th smallest of the n elements of data on the fast
+// path of Ordered if it's one of the slice types of the sort package, or an
+// OrderedSlicer. It reports whether it did.
+func selectSlice(data sort.Interface, n, k int) bool {
+	if selectSortSlice(data, n, k) {
+		return true
+	}
+	if x, ok := data.(OrderedSlicer); ok {
+		switch s := x.OrderedSlice().(type) {
+		case []int:
+			return selectOrdered(s, n, k)
+		case []int8:
+			return selectOrdered(s, n, k)
+		case []int16:
+			return selectOrdered(s, n, k)
+		case []int32:
+			return selectOrdered(s, n, k)
+		case []int64:
+			return selectOrdered(s, n, k)
+		case []uint:
+			return selectOrdered(s, n, k)
+		case []uint8:
+			return selectOrdered(s, n, k)
+		case []uint16:
+			return selectOrdered(s, n, k)
+		case []uint32:
+			return selectOrdered(s, n, k)
+		case []uint64:
+			return selectOrdered(s, n, k)
+		case []uintptr:
+			return selectOrdered(s, n, k)
+		case []float32:
+			return selectFloat(s, n, k)
+		case []float64:
+			return selectFloat(s, n, k)
+		case []string:
+			return selectOrdered(s, n, k)
+		}
+	}
+	return false
+}
+
+// selectSortSlice is the part of selectSlice for the slice types of the sort
+// package. Unlike OrderedSlice calls, it doesn't make data escape, so SelectT
+// can use it without allocating.
+func selectSortSlice(data any, n, k int) bool {
+	switch x := data.(type) {
+	case sort.IntSlice:
+		return selectOrdered([]int(x), n, k)
+	case sort.Float64Slice:
+		return selectFloat([]float64(x), n, k)
+	case sort.StringSlice:
+		return selectOrdered([]string(x), n, k)
+	}
+	return false
+}
+
+func selectOrdered[T cmp.Ordered](data []T, n, k int) bool {
+	if len(data) != n {
+		return false
+	}
+	pdqselectOrdered(data, 0, n, k-1, bits.Len(uint(n)))
+	return true
+}
+
+func selectFloat[T ~float32 | ~float64](data []T, n, k int) bool {
+	if len(data) != n {
+		return false
+	}
+	Float(data, k, NaNsFirst)
+	return true
+}
@@ -0,0 +1,89 @@
+package pdqselect
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// backedInts is an OrderedSlicer whose Less and Swap must not be called.
+type backedInts []int32
+
+func (x backedInts) Len() int           { return len(x) }
+func (x backedInts) Less(i, j int) bool { panic("Less called on an OrderedSlicer") }
+func (x backedInts) Swap(i, j int)      { panic("Swap called on an OrderedSlicer") }
+func (x backedInts) OrderedSlice() any  { return []int32(x) }
+
+// durations is an OrderedSlicer with an element type Select doesn't know, so it
+// must fall back to Less and Swap.
+type durations []time.Duration
+
+func (x durations) Len() int           { return len(x) }
+func (x durations) Less(i, j int) bool { return x[i] < x[j] }
+func (x durations) Swap(i, j int)      { x[i], x[j] = x[j], x[i] }
+func (x durations) OrderedSlice() any  { return []time.Duration(x) }
+
+func TestSelectDispatch(t *testing.T) {
+	for _, c := range selectCases() {
+		t.Run("StringSlice/"+c.name, func(t *testing.T) {
+			data := make([]string, len(c.input))
+			for i, x := range c.input {
+				data[i] = strconv.Itoa(x)
+			}
+			Select(sort.StringSlice(data), c.k)
+			testSelected(t, data, c.k, cmp.Compare[string])
+		})
+
+		t.Run("Float64Slice/"+c.name, func(t *testing.T) {
+			data := make([]float64, len(c.input))
+			for i, x := range c.input {
+				data[i] = float64(x % 100)
+				if x%7 == 0 {
+					data[i] = math.NaN()
+				}
+			}
+			Select(sort.Float64Slice(data), c.k)
+			testSelected(t, data, c.k, cmp.Compare[float64])
+		})
+
+		t.Run("OrderedSlicer/"+c.name, func(t *testing.T) {
+			data := make([]int32, len(c.input))
+			for i, x := range c.input {
+				data[i] = int32(x)
+			}
+			Select(backedInts(data), c.k)
+			testSelected(t, data, c.k, cmp.Compare[int32])
+		})
+
+		t.Run("OrderedSlicer/fallback/"+c.name, func(t *testing.T) {
+			data := make([]time.Duration, len(c.input))
+			for i, x := range c.input {
+				data[i] = time.Duration(x)
+			}
+			Select(durations(data), c.k)
+			testSelected(t, data, c.k, cmp.Compare[time.Duration])
+		})
+	}
+}
+
+// testSelected checks that data, which holds the elements of the input after
+// selecting, is partitioned around its k-th smallest element.
+func testSelected[E any](t *testing.T, data []E, k int, cmp func(a, b E) int) {
+	t.Helper()
+
+	sorted := slices.Clone(data)
+	slices.SortFunc(sorted, cmp)
+
+	if cmp(data[k-1], sorted[k-1]) != 0 {
+		t.Fatalf("k=%d: k-th element (%v) does not match sorted input (%v)", k, data[k-1], sorted[k-1])
+	}
+	for i, x := range data {
+		if c := cmp(x, data[k-1]); (i < k && c > 0) || (i >= k && c < 0) {
+			t.Fatalf("k=%d: element at index %d (%v) isn't partitioned around %v", k, i, x, data[k-1])
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package pdqselect
+
+import "math/bits"
+
+// NaNPolicy controls where Float places NaNs in the order of the elements.
+type NaNPolicy int
+
+const (
+	// NaNsFirst orders NaNs before any other value, as cmp.Compare does.
+	NaNsFirst NaNPolicy = iota
+	// NaNsLast orders NaNs after any other value. This also serves to leave NaNs
+	// out of the selection, since they don't affect the ranks of other values.
+	NaNsLast
+)
+
+// Float is a specialized version of Ordered for floating-point data that may
+// contain NaNs, which Ordered doesn't handle, since NaN compares false to
+// everything. It moves the NaNs to one end of the data as the NaNPolicy
+// dictates and then selects among the rest of the values with the same fast
+// path as Ordered, so it's as fast as Ordered and faster than Func with
+// cmp.Compare. It returns the number of NaNs in the data.
+//
+// Like cmp.Compare, Float treats -0.0 and +0.0 as equal, so either may be
+// selected when both are among the data.
+//
+// If k isn't in [1, n], Float leaves the data untouched and returns 0.
+func Float[T ~float32 | ~float64](data []T, k int, nans NaNPolicy) int {
+	n := len(data)
+	if k < 1 || k > n {
+		return 0
+	}
+
+	if nans == NaNsFirst {
+		m := partitionNaNs(data, true)
+		if k > m {
+			pdqselectOrdered(data, m, n, k-1, bits.Len(uint(n-m)))
+		}
+		return m
+	}
+
+	m := n - partitionNaNs(data, false)
+	if k <= n-m {
+		pdqselectOrdered(data, 0, n-m, k-1, bits.Len(uint(n-m)))
+	}
+	return m
+}
+
+// partitionNaNs moves the NaNs in data to its front if first is set, and to
+// its back otherwise. It returns the index of the first element of the back.
+func partitionNaNs[T ~float32 | ~float64](data []T, first bool) int {
+	i, j := 0, len(data)-1
+	for {
+		for i <= j && (data[i] != data[i]) == first {
+			i++
+		}
+		for i <= j && (data[j] != data[j]) != first {
+			j--
+		}
+		if i >= j {
+			return i
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
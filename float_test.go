@@ -0,0 +1,106 @@
+package pdqselect
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestFloat(t *testing.T) {
+	rng := rand.New(rand.NewPCG(42, 42)) // Only picks which elements become NaNs
+
+	for _, c := range selectCasesOutOfRange() {
+		for _, nans := range []float64{0, 0.1, 0.5, 1} {
+			// Turn some elements into NaNs and some into signed zeros.
+			input := make([]float64, len(c.input))
+			for i, x := range c.input {
+				input[i] = float64(x % 100)
+				switch r := rng.Float64(); {
+				case r < nans:
+					input[i] = math.NaN()
+				case r < nans+0.05:
+					input[i] = math.Copysign(0, -1)
+				}
+			}
+			name := fmt.Sprintf("%s/nans=%v", c.name, nans)
+
+			t.Run("NaNsFirst/"+name, func(t *testing.T) {
+				testFloat(t, input, c.k, NaNsFirst, cmp.Compare[float64])
+			})
+
+			t.Run("NaNsLast/"+name, func(t *testing.T) {
+				testFloat(t, input, c.k, NaNsLast, func(a, b float64) int {
+					if a != a || b != b {
+						return -cmp.Compare(a, b)
+					}
+					return cmp.Compare(a, b)
+				})
+			})
+		}
+	}
+}
+
+func testFloat(t *testing.T, input []float64, k int, nans NaNPolicy, compare func(a, b float64) int) {
+	t.Helper()
+
+	data := slices.Clone(input)
+	m := Float(data, k, nans)
+
+	if k < 1 || k > len(input) {
+		if m != 0 || !slices.EqualFunc(data, input, func(a, b float64) bool { return math.Float64bits(a) == math.Float64bits(b) }) {
+			t.Fatalf("k=%d: data was modified for out of range k", k)
+		}
+		return
+	}
+
+	if want := len(slices.DeleteFunc(slices.Clone(input), func(x float64) bool { return x == x })); m != want {
+		t.Fatalf("k=%d: got %d NaNs, want %d", k, m, want)
+	}
+
+	sorted := slices.Clone(input)
+	slices.SortFunc(sorted, compare)
+
+	if compare(data[k-1], sorted[k-1]) != 0 {
+		t.Fatalf("k=%d: k-th element (%v) does not match sorted input (%v)", k, data[k-1], sorted[k-1])
+	}
+
+	for i, x := range data {
+		if c := compare(x, data[k-1]); (i < k && c > 0) || (i >= k && c < 0) {
+			t.Fatalf("k=%d: element at index %d (%v) isn't partitioned around %v\ndata: %v", k, i, x, data[k-1], data)
+		}
+	}
+}
+
+func BenchmarkFloat(b *testing.B) {
+	rng := rand.New(rand.NewPCG(42, 42)) // Static seed for consistent benchmarks
+	const n = 1e5
+
+	data := make([]float64, n)
+	for i := range data {
+		data[i] = rng.Float64()
+		if i%100 == 0 {
+			data[i] = math.NaN()
+		}
+	}
+
+	for _, k := range []int{10, n / 2} {
+		benchName := fmt.Sprintf("n=%d/k=%d", int(n), k)
+
+		b.Run("fn=Func/"+benchName, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dataCopy := slices.Clone(data)
+				Func(dataCopy, k, cmp.Compare)
+			}
+		})
+
+		b.Run("fn=Float/"+benchName, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dataCopy := slices.Clone(data)
+				Float(dataCopy, k, NaNsFirst)
+			}
+		})
+	}
+}
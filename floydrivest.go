@@ -0,0 +1,224 @@
+package pdqselect
+
+import (
+	"math"
+	"math/bits"
+)
+
+// floydRivestCutoff is the length from which Func and FuncPtr select with
+// floydRivestCmpFunc and floydRivestPtrCmpFunc. Below it, sampling doesn't
+// save enough comparisons to pay for itself.
+const floydRivestCutoff = 1 << 14
+
+// floydRivestSample returns the size of the sample to draw from n elements and
+// how far apart, in that sample, to pick two pivots around the rank of the
+// element to select, following Floyd and Rivest: a sample of n^(2/3) elements,
+// with pivots about a standard deviation of the rank in the sample apart.
+func floydRivestSample(n int) (size, gap int) {
+	z := math.Log(float64(n))
+	s := 0.5 * math.Exp(2*z/3)
+	sd := 0.5 * math.Sqrt(z*s*(float64(n)-s)/float64(n))
+	return int(s), int(sd) + 1
+}
+
+// floydRivestCmpFunc places the element of rank k of data[a:b] at index k, with
+// the smaller elements before it and the larger ones after it, as
+// pdqselectCmpFunc does. It recursively selects two pivots from a sample of the
+// data that likely bracket the k-th element, partitions the data in three around them,
+// and carries on with the elements between them, which are few. This takes
+// about n + min(k, n-k) + o(n) comparisons, against 2n to 3n for
+// pdqselectCmpFunc, but moves elements around more, so it only pays off when
+// comparisons are costly relative to swaps or the data is large.
+//
+// It leaves short ranges to pdqselectCmpFunc, as well as data that looks presorted,
+// which pdqselectCmpFunc selects from in a single pass, and ranges that the pivots
+// don't narrow down as expected.
+func floydRivestCmpFunc[E any](data []E, a, b, k int, cmp func(a, b E) int) {
+	for b-a >= floydRivestCutoff && k != a && k != b-1 {
+		n := b - a
+		size, gap := floydRivestSample(n)
+
+		// Leave data that looks presorted to pdqselectCmpFunc, which selects in a
+		// single pass over it, rather than shuffling it around by sampling.
+		ascending, descending := true, true
+		for i := 1; i < size && (ascending || descending); i++ {
+			c := cmp(data[a+(i-1)*n/size], data[a+i*n/size])
+			ascending = ascending && c <= 0
+			descending = descending && c >= 0
+		}
+		if ascending || descending {
+			break
+		}
+
+		// Gather a sample of evenly spaced elements in data[sa:sb], at the same
+		// relative position as k, and select the pivots u and v within it.
+		sa := k - int(float64(k-a)*float64(size)/float64(n))
+		sb := sa + size
+		for i := range size {
+			j := a + i*n/size
+			data[sa+i], data[j] = data[j], data[sa+i]
+		}
+		ku, kv := max(sa, k-gap), min(sb-1, k+gap)
+		floydRivestCmpFunc(data, sa, sb, ku, cmp)
+		floydRivestCmpFunc(data, ku+1, sb, kv, cmp)
+		if ku < k && k < kv {
+			floydRivestCmpFunc(data, ku+1, kv, k, cmp)
+		}
+		u, v := data[ku], data[kv]
+
+		// If the k-th element of the sample equals one of the pivots, it's likely
+		// repeated many times in the data. Partitioning around it alone puts all
+		// its copies in the middle, where k most likely is.
+		if cmp(u, data[k]) == 0 {
+			v = u
+		} else if cmp(data[k], v) == 0 {
+			u = v
+		}
+
+		// Partition data[a:b] into the elements less than u, those between u
+		// and v, and those greater than v, comparing each element first with
+		// the pivot most elements are expected to be beyond.
+		lt, i, gt := a, a, b
+		if k-a < n/2 {
+			for i < gt {
+				if x := data[i]; cmp(v, x) < 0 {
+					gt--
+					data[i], data[gt] = data[gt], data[i]
+				} else if cmp(x, u) < 0 {
+					data[i], data[lt] = data[lt], data[i]
+					lt++
+					i++
+				} else {
+					i++
+				}
+			}
+		} else {
+			for i < gt {
+				if x := data[i]; cmp(x, u) < 0 {
+					data[i], data[lt] = data[lt], data[i]
+					lt++
+					i++
+				} else if cmp(v, x) < 0 {
+					gt--
+					data[i], data[gt] = data[gt], data[i]
+				} else {
+					i++
+				}
+			}
+		}
+
+		switch {
+		case k < lt:
+			b = lt
+		case k >= gt:
+			a = gt
+		case cmp(u, v) == 0:
+			return // All the elements between u and v are equal
+		default:
+			a, b = lt, gt
+		}
+
+		// Leave it to pdqselectCmpFunc if the range didn't shrink as expected,
+		// which happens when the sample isn't representative of the data.
+		if b-a > n/2 {
+			break
+		}
+	}
+	pdqselectCmpFunc(data, a, b, k, bits.Len(uint(b-a)), cmp)
+}
+
+// floydRivestPtrCmpFunc is floydRivestCmpFunc for FuncPtr, whose cmp takes
+// pointers to the elements.
+func floydRivestPtrCmpFunc[E any](data []E, a, b, k int, cmp func(a, b *E) int) {
+	for b-a >= floydRivestCutoff && k != a && k != b-1 {
+		n := b - a
+		size, gap := floydRivestSample(n)
+
+		// Leave data that looks presorted to pdqselectPtrCmpFunc, which selects in
+		// a single pass over it, rather than shuffling it around by sampling.
+		ascending, descending := true, true
+		for i := 1; i < size && (ascending || descending); i++ {
+			c := cmp(&data[a+(i-1)*n/size], &data[a+i*n/size])
+			ascending = ascending && c <= 0
+			descending = descending && c >= 0
+		}
+		if ascending || descending {
+			break
+		}
+
+		// Gather a sample of evenly spaced elements in data[sa:sb], at the same
+		// relative position as k, and select the pivots u and v within it.
+		sa := k - int(float64(k-a)*float64(size)/float64(n))
+		sb := sa + size
+		for i := range size {
+			j := a + i*n/size
+			data[sa+i], data[j] = data[j], data[sa+i]
+		}
+		ku, kv := max(sa, k-gap), min(sb-1, k+gap)
+		floydRivestPtrCmpFunc(data, sa, sb, ku, cmp)
+		floydRivestPtrCmpFunc(data, ku+1, sb, kv, cmp)
+		if ku < k && k < kv {
+			floydRivestPtrCmpFunc(data, ku+1, kv, k, cmp)
+		}
+		u, v := data[ku], data[kv]
+
+		// If the k-th element of the sample equals one of the pivots, it's likely
+		// repeated many times in the data. Partitioning around it alone puts all
+		// its copies in the middle, where k most likely is.
+		if cmp(&u, &data[k]) == 0 {
+			v = u
+		} else if cmp(&data[k], &v) == 0 {
+			u = v
+		}
+
+		// Partition data[a:b] into the elements less than u, those between u
+		// and v, and those greater than v, comparing each element first with
+		// the pivot most elements are expected to be beyond.
+		lt, i, gt := a, a, b
+		if k-a < n/2 {
+			for i < gt {
+				if x := &data[i]; cmp(&v, x) < 0 {
+					gt--
+					data[i], data[gt] = data[gt], data[i]
+				} else if cmp(x, &u) < 0 {
+					data[i], data[lt] = data[lt], data[i]
+					lt++
+					i++
+				} else {
+					i++
+				}
+			}
+		} else {
+			for i < gt {
+				if x := &data[i]; cmp(x, &u) < 0 {
+					data[i], data[lt] = data[lt], data[i]
+					lt++
+					i++
+				} else if cmp(&v, x) < 0 {
+					gt--
+					data[i], data[gt] = data[gt], data[i]
+				} else {
+					i++
+				}
+			}
+		}
+
+		switch {
+		case k < lt:
+			b = lt
+		case k >= gt:
+			a = gt
+		case cmp(&u, &v) == 0:
+			return // All the elements between u and v are equal
+		default:
+			a, b = lt, gt
+		}
+
+		// Leave it to pdqselectPtrCmpFunc if the range didn't shrink as expected,
+		// which happens when the sample isn't representative of the data.
+		if b-a > n/2 {
+			break
+		}
+	}
+	pdqselectPtrCmpFunc(data, a, b, k, bits.Len(uint(b-a)), cmp)
+}
@@ -0,0 +1,280 @@
+package pdqselect
+
+import (
+	"cmp"
+	"fmt"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestFloydRivestCmpFunc(t *testing.T) {
+	now := time.Now().UnixNano()
+	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
+
+	for _, dist := range distributions {
+		for _, size := range []int{floydRivestCutoff - 1, floydRivestCutoff, 100000} {
+			input := generateSlice(rng, size, dist)
+
+			for _, k := range []int{1, 2, size / 100, size / 2, size - size/100, size - 1, size} {
+				name := fmt.Sprintf("%s/n=%d/k=%d", dist, size, k)
+
+				t.Run("Func/"+name, func(t *testing.T) {
+					testSelect(t, input, 0, size, k, "Func", func(slice []int, a, b, k int) {
+						Func(slice, k, cmp.Compare)
+					})
+				})
+
+				t.Run("FuncPtr/"+name, func(t *testing.T) {
+					testSelect(t, input, 0, size, k, "FuncPtr", func(slice []int, a, b, k int) {
+						FuncPtr(slice, k, func(a, b *int) int { return cmp.Compare(*a, *b) })
+					})
+				})
+
+				// Leave some elements on both sides of the range to check they're untouched.
+				padded := slices.Concat([]int{-1, -1}, input, []int{-1})
+				t.Run("floydRivestCmpFunc/subrange/"+name, func(t *testing.T) {
+					testSelect(t, padded, 2, size+2, k, "floydRivestCmpFunc", func(slice []int, a, b, k int) {
+						floydRivestCmpFunc(slice, a, b, a+k-1, cmp.Compare)
+					})
+				})
+
+				t.Run("floydRivestPtrCmpFunc/subrange/"+name, func(t *testing.T) {
+					testSelect(t, padded, 2, size+2, k, "floydRivestPtrCmpFunc", func(slice []int, a, b, k int) {
+						floydRivestPtrCmpFunc(slice, a, b, a+k-1, func(a, b *int) int { return cmp.Compare(*a, *b) })
+					})
+				})
+			}
+		}
+	}
+}
+
+func TestFloydRivestCmpFuncComparisons(t *testing.T) {
+	rng := rand.New(rand.NewPCG(42, 42))
+	const n = 100000
+
+	for _, dist := range []string{"random", "organ_pipe", "sawtooth", "zipf"} {
+		input := generateSlice(rng, n, dist)
+
+		for _, k := range []int{n / 100, n / 2} {
+			t.Run(fmt.Sprintf("%s/k=%d", dist, k), func(t *testing.T) {
+				var want, got int
+				pdqselectCmpFunc(slices.Clone(input), 0, n, k-1, bits.Len(uint(n)), func(a, b int) int {
+					want++
+					return cmp.Compare(a, b)
+				})
+				floydRivestCmpFunc(slices.Clone(input), 0, n, k-1, func(a, b int) int {
+					got++
+					return cmp.Compare(a, b)
+				})
+				if got >= want {
+					t.Fatalf("floydRivestCmpFunc made %d comparisons, pdqselectCmpFunc %d", got, want)
+				}
+			})
+		}
+	}
+}
+
+// TestFloydRivestCmpFuncAdversary checks that Func and FuncPtr take a linear
+// number of comparisons above floydRivestCutoff against McIlroy's adversary,
+// which picks the order of the elements in the sample and the narrowed range
+// as bad as it can.
+func TestFloydRivestCmpFuncAdversary(t *testing.T) {
+	for _, size := range []int{floydRivestCutoff, 1e5, 1e6} {
+		for _, k := range []int{size / 4, size / 2, size - 100} {
+			for _, fn := range []string{"Func", "FuncPtr"} {
+				t.Run(fmt.Sprintf("%s/n=%d/k=%d", fn, size, k), func(t *testing.T) {
+					adv := newAdversary(size)
+					data := make([]int, size)
+					for i := range data {
+						data[i] = i
+					}
+
+					comparisons := 0
+					compare := func(a, b int) int {
+						comparisons++
+						return adv.compare(a, b)
+					}
+					switch fn {
+					case "Func":
+						Func(data, k, compare)
+					case "FuncPtr":
+						FuncPtr(data, k, func(a, b *int) int { return compare(*a, *b) })
+					}
+					if max := 25 * size; comparisons > max {
+						t.Fatalf("made %d comparisons, want at most %d", comparisons, max)
+					}
+
+					vals := make([]int, size)
+					for i, x := range data {
+						vals[i] = adv.val[x]
+					}
+					testSelected(t, vals, k, cmp.Compare[int])
+				})
+			}
+		}
+	}
+}
+
+func BenchmarkFloydRivestCmpFunc(b *testing.B) {
+	rng := rand.New(rand.NewPCG(42, 42)) // Static seed for consistent benchmarks
+	const n = 1e6
+
+	for _, dist := range distributions {
+		data := generateSlice(rng, n, dist)
+		keys := make([]string, n)
+		for i, x := range data {
+			keys[i] = strconv.Itoa(x)
+		}
+		keysCopy := make([]string, n)
+
+		for _, k := range []int{n / 100, n / 2} {
+			benchName := fmt.Sprintf("n=%d/k=%d/%s", int(n), k, dist)
+
+			b.Run("fn=pdqselectCmpFunc/"+benchName, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					copy(keysCopy, keys)
+					pdqselectCmpFunc(keysCopy, 0, n, k-1, bits.Len(uint(n)), cmp.Compare[string])
+				}
+			})
+
+			b.Run("fn=floydRivestCmpFunc/"+benchName, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					copy(keysCopy, keys)
+					floydRivestCmpFunc(keysCopy, 0, n, k-1, cmp.Compare[string])
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkFloydRivestOrdered backs the choice to leave Ordered on the
+// pdqselect loop: with comparisons as cheap as those of ordered types, the
+// comparisons Floyd–Rivest saves don't pay for the elements it moves around
+// on random and zipf data, even though it's faster on some patterned data.
+func BenchmarkFloydRivestOrdered(b *testing.B) {
+	rng := rand.New(rand.NewPCG(42, 42)) // Static seed for consistent benchmarks
+	const n = 1e6
+
+	for _, dist := range distributions {
+		data := generateSlice(rng, n, dist)
+		dataCopy := make([]int, n)
+
+		for _, k := range []int{n / 100, n / 2} {
+			benchName := fmt.Sprintf("n=%d/k=%d/%s", int(n), k, dist)
+
+			b.Run("fn=Ordered/"+benchName, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					copy(dataCopy, data)
+					Ordered(dataCopy, k)
+				}
+			})
+
+			b.Run("fn=floydRivestOrdered/"+benchName, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					copy(dataCopy, data)
+					floydRivestOrdered(dataCopy, 0, n, k-1)
+				}
+			})
+		}
+	}
+}
+
+// floydRivestOrdered is floydRivestCmpFunc for ordered types, for
+// BenchmarkFloydRivestOrdered to compare with Ordered.
+func floydRivestOrdered[E cmp.Ordered](data []E, a, b, k int) {
+	for b-a >= floydRivestCutoff && k != a && k != b-1 {
+		n := b - a
+		size, gap := floydRivestSample(n)
+
+		// Leave data that looks presorted to pdqselectOrdered, which selects in a
+		// single pass over it, rather than shuffling it around by sampling.
+		ascending, descending := true, true
+		for i := 1; i < size && (ascending || descending); i++ {
+			c := cmp.Compare(data[a+(i-1)*n/size], data[a+i*n/size])
+			ascending = ascending && c <= 0
+			descending = descending && c >= 0
+		}
+		if ascending || descending {
+			break
+		}
+
+		// Gather a sample of evenly spaced elements in data[sa:sb], at the same
+		// relative position as k, and select the pivots u and v within it.
+		sa := k - int(float64(k-a)*float64(size)/float64(n))
+		sb := sa + size
+		for i := range size {
+			j := a + i*n/size
+			data[sa+i], data[j] = data[j], data[sa+i]
+		}
+		ku, kv := max(sa, k-gap), min(sb-1, k+gap)
+		floydRivestOrdered(data, sa, sb, ku)
+		floydRivestOrdered(data, ku+1, sb, kv)
+		if ku < k && k < kv {
+			floydRivestOrdered(data, ku+1, kv, k)
+		}
+		u, v := data[ku], data[kv]
+
+		// If the k-th element of the sample equals one of the pivots, it's likely
+		// repeated many times in the data. Partitioning around it alone puts all
+		// its copies in the middle, where k most likely is.
+		if u == data[k] {
+			v = u
+		} else if data[k] == v {
+			u = v
+		}
+
+		// Partition data[a:b] into the elements less than u, those between u
+		// and v, and those greater than v, comparing each element first with
+		// the pivot most elements are expected to be beyond.
+		lt, i, gt := a, a, b
+		if k-a < n/2 {
+			for i < gt {
+				if x := data[i]; v < x {
+					gt--
+					data[i], data[gt] = data[gt], data[i]
+				} else if x < u {
+					data[i], data[lt] = data[lt], data[i]
+					lt++
+					i++
+				} else {
+					i++
+				}
+			}
+		} else {
+			for i < gt {
+				if x := data[i]; x < u {
+					data[i], data[lt] = data[lt], data[i]
+					lt++
+					i++
+				} else if v < x {
+					gt--
+					data[i], data[gt] = data[gt], data[i]
+				} else {
+					i++
+				}
+			}
+		}
+
+		switch {
+		case k < lt:
+			b = lt
+		case k >= gt:
+			a = gt
+		case u == v:
+			return // All the elements between u and v are equal
+		default:
+			a, b = lt, gt
+		}
+
+		// Leave it to pdqselectOrdered if the range didn't shrink as expected,
+		// which happens when the sample isn't representative of the data.
+		if b-a > n/2 {
+			break
+		}
+	}
+	pdqselectOrdered(data, a, b, k, bits.Len(uint(b-a)))
+}
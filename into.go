@@ -0,0 +1,107 @@
+package pdqselect
+
+import (
+	"cmp"
+	"math/bits"
+)
+
+// Scratch holds the buffer OrderedInto and FuncInto filter the elements of src
+// into. The zero value is ready to use. Reusing a Scratch across calls, or
+// pooling Scratches with a sync.Pool, saves allocating a new buffer on every
+// call, so repeated queries don't allocate in steady state. A Scratch must not
+// be used by several calls at once.
+type Scratch[E any] struct {
+	buf []E
+}
+
+// grow returns the empty buffer of s with room for at least size elements.
+func (s *Scratch[E]) grow(size int) []E {
+	if cap(s.buf) < size {
+		s.buf = make([]E, 0, size)
+	}
+	return s.buf[:0]
+}
+
+// OrderedInto is a non-destructive version of Ordered. It leaves src untouched
+// and appends the smallest k elements of src to dst[:0], in no particular
+// order other than the k-th smallest element being the last one, returning
+// the updated slice.
+//
+// Rather than copying all of src, it only keeps the elements that may still be
+// among the smallest k in a buffer of max(2k, 1024) elements, held by s. Whenever
+// that buffer fills up, it's cut down to the smallest k with Ordered, and
+// elements that aren't smaller than the largest of those are skipped from then
+// on. This takes O(n) time and memory proportional to k rather than to n. It's
+// fastest when most elements get skipped, as with random data, and slowest
+// when they don't, as with data in decreasing order.
+//
+// If s is nil, a buffer is allocated for the call. Reusing both the returned
+// slice as dst and s in later calls avoids allocations in steady state. If k is
+// out of range, dst[:0] is returned.
+func OrderedInto[T cmp.Ordered](dst, src []T, k int, s *Scratch[T]) []T {
+	n := len(src)
+	if k < 1 || k > n {
+		return dst[:0]
+	}
+	if s == nil {
+		s = new(Scratch[T])
+	}
+
+	size := min(max(2*k, 1024), n)
+	buf := s.grow(size)
+
+	if size == n {
+		buf = append(buf, src...)
+		pdqselectOrdered(buf, 0, n, k-1, bits.Len(uint(n)))
+		return append(dst[:0], buf[:k]...)
+	}
+
+	buf = append(buf, src[:size]...)
+	for _, x := range src[size:] {
+		if len(buf) == size {
+			pdqselectOrdered(buf, 0, size, k-1, bits.Len(uint(size)))
+			buf = buf[:k]
+		}
+		if x < buf[k-1] {
+			buf = append(buf, x)
+		}
+	}
+
+	pdqselectOrdered(buf, 0, len(buf), k-1, bits.Len(uint(len(buf))))
+	return append(dst[:0], buf[:k]...)
+}
+
+// FuncInto is a generic version of OrderedInto that allows the caller to provide
+// a custom comparison function to determine the order of elements.
+func FuncInto[E any](dst, src []E, k int, s *Scratch[E], cmp func(a, b E) int) []E {
+	n := len(src)
+	if k < 1 || k > n {
+		return dst[:0]
+	}
+	if s == nil {
+		s = new(Scratch[E])
+	}
+
+	size := min(max(2*k, 1024), n)
+	buf := s.grow(size)
+
+	if size == n {
+		buf = append(buf, src...)
+		pdqselectCmpFunc(buf, 0, n, k-1, bits.Len(uint(n)), cmp)
+		return append(dst[:0], buf[:k]...)
+	}
+
+	buf = append(buf, src[:size]...)
+	for _, x := range src[size:] {
+		if len(buf) == size {
+			pdqselectCmpFunc(buf, 0, size, k-1, bits.Len(uint(size)), cmp)
+			buf = buf[:k]
+		}
+		if cmp(x, buf[k-1]) < 0 {
+			buf = append(buf, x)
+		}
+	}
+
+	pdqselectCmpFunc(buf, 0, len(buf), k-1, bits.Len(uint(len(buf))), cmp)
+	return append(dst[:0], buf[:k]...)
+}
@@ -0,0 +1,131 @@
+package pdqselect
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestOrderedInto(t *testing.T) {
+	// Share the scratch buffers between subtests to check they're reused correctly.
+	var scratch Scratch[int]
+	var funcScratch Scratch[int]
+
+	for _, c := range selectCasesOutOfRange() {
+		t.Run("OrderedInto/"+c.name, func(t *testing.T) {
+			testSelectInto(t, c.input, c.k, func(dst, src []int) []int {
+				return OrderedInto(dst, src, c.k, &scratch)
+			})
+		})
+
+		t.Run("OrderedInto/nil/"+c.name, func(t *testing.T) {
+			testSelectInto(t, c.input, c.k, func(dst, src []int) []int {
+				return OrderedInto(dst, src, c.k, nil)
+			})
+		})
+
+		t.Run("FuncInto/"+c.name, func(t *testing.T) {
+			testSelectInto(t, c.input, c.k, func(dst, src []int) []int {
+				return FuncInto(dst, src, c.k, &funcScratch, cmp.Compare)
+			})
+		})
+	}
+}
+
+func TestOrderedIntoAllocs(t *testing.T) {
+	rng := rand.New(rand.NewPCG(42, 42))
+	src := generateSlice(rng, 10000, "random")
+	var dst []int
+	var scratch Scratch[int]
+
+	allocs := testing.AllocsPerRun(10, func() {
+		dst = OrderedInto(dst, src, 100, &scratch)
+	})
+	if allocs != 0 {
+		t.Errorf("OrderedInto allocated %v times with a reused dst and Scratch", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(10, func() {
+		dst = FuncInto(dst, src, 100, &scratch, cmp.Compare)
+	})
+	if allocs != 0 {
+		t.Errorf("FuncInto allocated %v times with a reused dst and Scratch", allocs)
+	}
+}
+
+func TestScratchReuse(t *testing.T) {
+	rng := rand.New(rand.NewPCG(42, 42))
+	var scratch Scratch[int]
+
+	got := OrderedInto(nil, generateSlice(rng, 10000, "random"), 100, &scratch)
+	want := slices.Clone(got)
+	OrderedInto(nil, generateSlice(rng, 10000, "reversed"), 100, &scratch)
+	if !slices.Equal(got, want) {
+		t.Fatal("the result of OrderedInto changed when its Scratch was reused")
+	}
+}
+
+func testSelectInto(t *testing.T, input []int, k int, selectInto func(dst, src []int) []int) {
+	t.Helper()
+
+	src := slices.Clone(input)
+	got := selectInto(nil, src)
+
+	if !slices.Equal(src, input) {
+		t.Fatalf("k=%d: src was modified\ninput: %v\nsrc:   %v", k, input, src)
+	}
+
+	if k < 1 || k > len(input) {
+		if len(got) != 0 {
+			t.Fatalf("k=%d: expected no elements for out of range k, got %v", k, got)
+		}
+		return
+	}
+
+	sorted := slices.Clone(input)
+	slices.Sort(sorted)
+
+	if len(got) != k {
+		t.Fatalf("k=%d: got %d elements", k, len(got))
+	}
+	if got[k-1] != sorted[k-1] {
+		t.Fatalf("k=%d: k-th element (%d) does not match sorted input (%d)", k, got[k-1], sorted[k-1])
+	}
+
+	got = slices.Clone(got)
+	slices.Sort(got)
+	if !slices.Equal(got, sorted[:k]) {
+		t.Fatalf("k=%d: got elements %v, want %v", k, got, sorted[:k])
+	}
+}
+
+func BenchmarkOrderedInto(b *testing.B) {
+	rng := rand.New(rand.NewPCG(42, 42)) // Static seed for consistent benchmarks
+	const n = 1e6
+
+	for _, k := range []int{10, 1000} {
+		for _, dist := range []string{"random", "sorted", "reversed", "mostly_sorted"} {
+			data := generateSlice(rng, n, dist)
+			benchName := fmt.Sprintf("n=%d/k=%d/%s", int(n), k, dist)
+
+			b.Run("fn=Ordered/"+benchName, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					dataCopy := slices.Clone(data)
+					Ordered(dataCopy, k)
+				}
+			})
+
+			b.Run("fn=OrderedInto/"+benchName, func(b *testing.B) {
+				b.ReportAllocs()
+				var dst []int
+				var scratch Scratch[int]
+				for i := 0; i < b.N; i++ {
+					dst = OrderedInto(dst, data, k, &scratch)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package pdqselect
+
+import (
+	"cmp"
+	"math/bits"
+)
+
+// FuncKey is a version of Func for orderings given by a key of each element
+// rather than by a comparison function. It calls key exactly once per element,
+// caching the keys in a slice of n elements which it selects on with the same
+// fast path as Ordered, moving the elements of data along with their keys.
+// This is the selection counterpart of the decorate-sort-undecorate idiom
+// (the Schwartzian transform).
+//
+// It pays off when computing a key is expensive compared to comparing keys,
+// such as when it involves parsing or hashing, since Func calls cmp, and thus
+// any work within it, several times per element.
+func FuncKey[E any, K cmp.Ordered](data []E, k int, key func(E) K) {
+	n := len(data)
+	if k < 1 || k > n {
+		return
+	}
+
+	keys := make([]K, n)
+	for i, x := range data {
+		keys[i] = key(x)
+	}
+	pdqselectOrderedKV(keys, 0, n, k-1, bits.Len(uint(n)), data)
+}
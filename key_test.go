@@ -0,0 +1,145 @@
+package pdqselect
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestFuncKey(t *testing.T) {
+	now := time.Now().UnixNano()
+	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
+
+	for _, dist := range []string{"random", "sorted", "reversed", "mostly_sorted", "organ_pipe", "sawtooth", "push_middle", "zipf"} {
+		for _, size := range []int{1, 10, 100, 1000} {
+			input := generateSlice(rng, size, dist)
+
+			for _, k := range []int{0, 1, 2, size / 2, size - 1, size, size + 1} {
+				name := fmt.Sprintf("%s/n=%d/k=%d", dist, size, k)
+
+				t.Run("FuncKey/"+name, func(t *testing.T) {
+					testFuncKey(t, input, k, func(data []string) {
+						FuncKey(data, k, parseKey(t))
+					})
+				})
+
+				if k < 1 || k > size {
+					continue
+				}
+
+				// Exercise the heap select fallback on its own.
+				t.Run("pdqselectOrderedKV/limit=0/"+name, func(t *testing.T) {
+					testFuncKey(t, input, k, func(data []string) {
+						keys := make([]int, len(data))
+						for i, s := range data {
+							keys[i] = parseKey(t)(s)
+						}
+						pdqselectOrderedKV(keys, 0, len(data), k-1, 0, data)
+					})
+				})
+			}
+		}
+	}
+}
+
+func TestFuncKeyCallsKeyOnce(t *testing.T) {
+	rng := rand.New(rand.NewPCG(42, 42))
+	data := generateSlice(rng, 1000, "random")
+
+	calls := 0
+	FuncKey(data, 500, func(x int) int {
+		calls++
+		return x
+	})
+	if calls != len(data) {
+		t.Errorf("key was called %d times, want %d", calls, len(data))
+	}
+}
+
+func parseKey(t *testing.T) func(string) int {
+	return func(s string) int {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+}
+
+func testFuncKey(t *testing.T, input []int, k int, funcKey func([]string)) {
+	t.Helper()
+
+	data := make([]string, len(input))
+	for i, x := range input {
+		data[i] = strconv.Itoa(x)
+	}
+	funcKey(data)
+
+	got := make([]int, len(data))
+	for i, s := range data {
+		got[i] = parseKey(t)(s)
+	}
+
+	sorted := slices.Clone(input)
+	slices.Sort(sorted)
+
+	if k < 1 || k > len(input) {
+		if !slices.Equal(got, input) {
+			t.Fatalf("k=%d: data was modified for out of range k", k)
+		}
+		return
+	}
+
+	if got[k-1] != sorted[k-1] {
+		t.Fatalf("k=%d: k-th element (%d) does not match sorted input (%d)", k, got[k-1], sorted[k-1])
+	}
+
+	kth := got[k-1]
+	for i, x := range got {
+		if (i < k && x > kth) || (i >= k && x < kth) {
+			t.Fatalf("k=%d: element at index %d (%d) isn't partitioned around %d", k, i, x, kth)
+		}
+	}
+
+	slices.Sort(got)
+	if !slices.Equal(got, sorted) {
+		t.Fatalf("k=%d: elements aren't a permutation of the input", k)
+	}
+}
+
+func BenchmarkFuncKey(b *testing.B) {
+	rng := rand.New(rand.NewPCG(42, 42)) // Static seed for consistent benchmarks
+	const n = 1e5
+
+	input := generateSlice(rng, n, "random")
+	data := make([]string, n)
+	for i, x := range input {
+		data[i] = strconv.Itoa(x)
+	}
+	key := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+
+	for _, k := range []int{10, n / 2} {
+		benchName := fmt.Sprintf("n=%d/k=%d", int(n), k)
+
+		b.Run("fn=Func/"+benchName, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dataCopy := slices.Clone(data)
+				Func(dataCopy, k, func(a, b string) int { return cmp.Compare(key(a), key(b)) })
+			}
+		})
+
+		b.Run("fn=FuncKey/"+benchName, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dataCopy := slices.Clone(data)
+				FuncKey(dataCopy, k, key)
+			}
+		})
+	}
+}
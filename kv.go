@@ -0,0 +1,63 @@
+package pdqselect
+
+import (
+	"cmp"
+	"math/bits"
+	"reflect"
+)
+
+// OrderedKV is like Ordered, but it reorders vals along with keys, as parallel
+// slices: whenever two keys are swapped, so are the values at the same indices.
+// Comparisons only involve the keys, on the same fast path as Ordered. It's
+// meant for columnar data, like scores and IDs kept in separate slices, which
+// would otherwise have to be zipped into a slice of structs for selection.
+//
+// If keys and vals have different lengths, OrderedKV does nothing.
+func OrderedKV[K cmp.Ordered, V any](keys []K, vals []V, k int) {
+	n := len(keys)
+	if k < 1 || k > n || len(vals) != n {
+		return
+	}
+	pdqselectOrderedKV(keys, 0, n, k-1, bits.Len(uint(n)), vals)
+}
+
+// OrderedWith is a variadic version of OrderedKV, which reorders any number of
+// follower slices along with keys. Comparisons stay on the fast path of
+// Ordered, with the positions of the keys tracked in a slice of n indices,
+// which is then used to swap the elements of each follower in O(n) time
+// through reflect.Swapper. Those swaps are much slower than the ones of
+// OrderedKV, so it's better suited for several followers than for one.
+//
+// OrderedWith panics if a follower isn't a slice. If a follower's length
+// differs from that of keys, OrderedWith does nothing.
+func OrderedWith[K cmp.Ordered](keys []K, k int, followers ...any) {
+	n := len(keys)
+	swaps := make([]func(i, j int), len(followers))
+	for i, f := range followers {
+		swaps[i] = reflect.Swapper(f)
+		if reflect.ValueOf(f).Len() != n {
+			return
+		}
+	}
+	if k < 1 || k > n {
+		return
+	}
+
+	perm := identity(nil, n)
+	pdqselectOrderedKV(keys, 0, n, k-1, bits.Len(uint(n)), perm)
+
+	// perm[i] is now the index the element at index i came from. Move the
+	// elements of the followers there by following each cycle of the
+	// permutation, marking the indices it visits as done.
+	for i := range perm {
+		j := i
+		for perm[j] != i {
+			next := perm[j]
+			for _, swap := range swaps {
+				swap(j, next)
+			}
+			perm[j], j = j, next
+		}
+		perm[j] = j
+	}
+}
@@ -0,0 +1,90 @@
+package pdqselect
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestOrderedKV(t *testing.T) {
+	for _, c := range selectCases() {
+		input := c.input
+
+		t.Run("OrderedKV/"+c.name, func(t *testing.T) {
+			testSelect(t, input, 0, len(input), c.k, "OrderedKV", func(slice []int, a, b, k int) {
+				ids := identity(nil, len(slice))
+				OrderedKV(slice, ids, k)
+				for i, id := range ids {
+					if slice[i] != input[id] {
+						t.Fatalf("k=%d: value at index %d wasn't moved along with its key", k, i)
+					}
+				}
+			})
+		})
+
+		t.Run("OrderedWith/"+c.name, func(t *testing.T) {
+			testSelect(t, input, 0, len(input), c.k, "OrderedWith", func(slice []int, a, b, k int) {
+				ids := identity(nil, len(slice))
+				names := make([]string, len(slice))
+				for i := range names {
+					names[i] = strconv.Itoa(i)
+				}
+				OrderedWith(slice, k, ids, names)
+				for i, id := range ids {
+					if slice[i] != input[id] || names[i] != strconv.Itoa(id) {
+						t.Fatalf("k=%d: followers at index %d weren't moved along with their key", k, i)
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestOrderedKVLengthMismatch(t *testing.T) {
+	keys := []int{3, 2, 1}
+
+	OrderedKV(keys, []int{1, 2}, 1)
+	if !slices.Equal(keys, []int{3, 2, 1}) {
+		t.Errorf("OrderedKV modified the keys despite a length mismatch: %v", keys)
+	}
+
+	OrderedWith(keys, 1, []int{1, 2, 3}, []int{1, 2})
+	if !slices.Equal(keys, []int{3, 2, 1}) {
+		t.Errorf("OrderedWith modified the keys despite a length mismatch: %v", keys)
+	}
+}
+
+func BenchmarkOrderedWith(b *testing.B) {
+	rng := rand.New(rand.NewPCG(42, 42)) // Static seed for consistent benchmarks
+	const n = 1e5
+
+	keys := generateSlice(rng, n, "random")
+	ids := identity(nil, n)
+	k := int(n / 2)
+
+	type pair struct{ key, id int }
+
+	b.Run("fn=Zipped", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			pairs := make([]pair, n)
+			for j := range pairs {
+				pairs[j] = pair{keys[j], ids[j]}
+			}
+			Func(pairs, k, func(a, b pair) int { return cmp.Compare(a.key, b.key) })
+		}
+	})
+
+	b.Run("fn=OrderedKV", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			OrderedKV(slices.Clone(keys), slices.Clone(ids), k)
+		}
+	})
+
+	b.Run("fn=OrderedWith", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			OrderedWith(slices.Clone(keys), k, slices.Clone(ids))
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package pdqselect
+
+import (
+	"cmp"
+	"math/bits"
+	"sort"
+)
+
+// Placement controls where the largest-k functions leave the selected elements.
+type Placement int
+
+const (
+	// Front places the k largest elements at indices 0, 1, ..., k-1,
+	// with the k-th largest element at index k-1.
+	Front Placement = iota
+	// Back places the k largest elements at indices n-k, ..., n-1,
+	// with the k-th largest element at index n-k.
+	Back
+)
+
+// SelectLargest swaps elements in the data provided so that the k largest
+// elements end up at the front or at the back of the data, as chosen by at.
+// It doesn't guarantee any particular order among the largest k elements.
+//
+// It selects the (n-k+1)-th smallest element with the same ascending comparisons
+// as Select, so there's no need to invert Less. Placing the result at the front
+// takes at most min(k, n-k) additional swaps.
+func SelectLargest(data sort.Interface, k int, at Placement) {
+	n := data.Len()
+	if k < 1 || k > n {
+		return
+	}
+	pdqselect(data, 0, n, n-k, bits.Len(uint(n)))
+	if at == Front {
+		m, kth := frontSwap(n, k)
+		swapRange(data, 0, n-m, m)
+		if kth != k-1 {
+			data.Swap(kth, k-1)
+		}
+	}
+}
+
+// OrderedLargest is a specialized version of SelectLargest that works with slices of
+// ordered types (i.e. types that implement the cmp.Ordered interface).
+func OrderedLargest[T cmp.Ordered](data []T, k int, at Placement) {
+	n := len(data)
+	if k < 1 || k > n {
+		return
+	}
+	pdqselectOrdered(data, 0, n, n-k, bits.Len(uint(n)))
+	if at == Front {
+		m, kth := frontSwap(n, k)
+		swapRangeOrdered(data, 0, n-m, m)
+		data[kth], data[k-1] = data[k-1], data[kth]
+	}
+}
+
+// FuncLargest is a generic version of SelectLargest that allows the caller to provide
+// a custom comparison function to determine the order of elements.
+func FuncLargest[E any](data []E, k int, at Placement, cmp func(a, b E) int) {
+	n := len(data)
+	if k < 1 || k > n {
+		return
+	}
+	floydRivestCmpFunc(data, 0, n, n-k, cmp)
+	if at == Front {
+		m, kth := frontSwap(n, k)
+		swapRangeCmpFunc(data, 0, n-m, m, cmp)
+		data[kth], data[k-1] = data[k-1], data[kth]
+	}
+}
+
+// frontSwap returns how many elements need to be swapped between the start and
+// the end of the data to move the k largest elements from data[n-k:] to data[:k],
+// and the index the k-th largest element, previously at n-k, ends up at.
+func frontSwap(n, k int) (m, kth int) {
+	if k <= n-k {
+		// data[n-k:] is swapped as a whole with data[:k].
+		return k, 0
+	}
+	// data[:n-k] holds the smaller elements and is swapped with data[k:],
+	// leaving data[n-k] in place.
+	return n - k, n - k
+}
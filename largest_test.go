@@ -0,0 +1,87 @@
+package pdqselect
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSelectLargest(t *testing.T) {
+	now := time.Now().UnixNano()
+	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
+
+	for _, dist := range []string{"random", "sorted", "reversed", "mostly_sorted", "sawtooth", "zipf"} {
+		for _, size := range []int{1, 2, 10, 100, 1000, floydRivestCutoff} {
+			input := generateSlice(rng, size, dist)
+
+			for _, k := range []int{0, 1, size / 3, size / 2, size/2 + 1, size - 1, size, size + 1} {
+				for _, at := range []Placement{Front, Back} {
+					name := fmt.Sprintf("%s/n=%d/k=%d/at=%d", dist, size, k, at)
+
+					t.Run("SelectLargest/"+name, func(t *testing.T) {
+						testSelectLargest(t, input, k, at, func(data []int) {
+							SelectLargest(sort.IntSlice(data), k, at)
+						})
+					})
+
+					t.Run("OrderedLargest/"+name, func(t *testing.T) {
+						testSelectLargest(t, input, k, at, func(data []int) {
+							OrderedLargest(data, k, at)
+						})
+					})
+
+					t.Run("FuncLargest/"+name, func(t *testing.T) {
+						testSelectLargest(t, input, k, at, func(data []int) {
+							FuncLargest(data, k, at, cmp.Compare)
+						})
+					})
+				}
+			}
+		}
+	}
+}
+
+func testSelectLargest(t *testing.T, input []int, k int, at Placement, selectLargest func([]int)) {
+	t.Helper()
+
+	output := slices.Clone(input)
+	selectLargest(output)
+
+	n := len(input)
+	if k < 1 || k > n {
+		if !slices.Equal(output, input) {
+			t.Fatalf("k=%d: out of range k modified the data\ninput:  %v\noutput: %v", k, input, output)
+		}
+		return
+	}
+
+	sorted := slices.Clone(input)
+	slices.Sort(sorted)
+	want := sorted[n-k:]
+
+	largest, rest, kth := output[:k], output[k:], k-1
+	if at == Back {
+		largest, rest, kth = output[n-k:], output[:n-k], n-k
+	}
+
+	if output[kth] != sorted[n-k] {
+		t.Fatalf("k=%d: k-th largest element at index %d (%d) doesn't match sorted input (%d)\noutput: %v",
+			k, kth, output[kth], sorted[n-k], output)
+	}
+
+	got := slices.Clone(largest)
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Fatalf("k=%d: largest elements don't match\nwant: %v\ngot:  %v", k, want, got)
+	}
+
+	for _, v := range rest {
+		if v > sorted[n-k] {
+			t.Fatalf("k=%d: element %d outside of the largest k is larger than the k-th largest (%d)\noutput: %v", k, v, sorted[n-k], output)
+		}
+	}
+}
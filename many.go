@@ -0,0 +1,290 @@
+package pdqselect
+
+import (
+	"cmp"
+	"math/bits"
+	"sort"
+)
+
+// SelectMany is like Select, but places several order statistics in a single call.
+// The ranks in ks are 1-based like k in Select and must be sorted in ascending order.
+// Ranks outside of [1, n] are ignored.
+//
+// On return, for every rank k in ks, the k-th smallest element is at index k-1,
+// all elements before it are smaller or equal, and all elements after it are
+// larger or equal. This means every gap between two consecutive ranks is
+// partitioned as well.
+//
+// Partitions that don't contain any of the requested ranks are never visited again,
+// so selecting m ranks costs O(n log m) on average instead of the O(n·m) of m
+// separate calls to Select.
+func SelectMany(data sort.Interface, ks []int) {
+	n := data.Len()
+	if ks = clampRanks(ks, n); len(ks) == 0 {
+		return
+	}
+	pdqselectMany(data, 0, n, ks, bits.Len(uint(n)))
+}
+
+// OrderedMany is a specialized version of SelectMany that works with slices of
+// ordered types (i.e. types that implement the cmp.Ordered interface).
+func OrderedMany[T cmp.Ordered](data []T, ks []int) {
+	n := len(data)
+	if ks = clampRanks(ks, n); len(ks) == 0 {
+		return
+	}
+	pdqselectManyOrdered(data, 0, n, ks, bits.Len(uint(n)))
+}
+
+// FuncMany is a generic version of SelectMany that allows the caller to provide
+// a custom comparison function to determine the order of elements.
+func FuncMany[E any](data []E, ks []int, cmp func(a, b E) int) {
+	n := len(data)
+	if ks = clampRanks(ks, n); len(ks) == 0 {
+		return
+	}
+	pdqselectManyFunc(data, 0, n, ks, bits.Len(uint(n)), cmp)
+}
+
+// clampRanks returns the sub-slice of the sorted ranks ks that lie within [1, n].
+func clampRanks(ks []int, n int) []int {
+	lo := sort.SearchInts(ks, 1)
+	hi := sort.SearchInts(ks, n+1)
+	if lo >= hi {
+		return nil
+	}
+	return ks[lo:hi]
+}
+
+// splitRanks splits the sorted 1-based ranks ks around the 0-based index mid.
+// It returns the ranks that fall before mid and the ones that fall after it.
+func splitRanks(ks []int, mid int) (left, right []int) {
+	i := sort.SearchInts(ks, mid+1)
+	j := i
+	for j < len(ks) && ks[j] == mid+1 {
+		j++
+	}
+	return ks[:i], ks[j:]
+}
+
+func pdqselectMany(data sort.Interface, a, b int, ks []int, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true
+		wasPartitioned = true
+	)
+
+	for len(ks) > 0 {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSort(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSort(data, a, b)
+			return
+		}
+
+		// Break patterns if the last partitioning was imbalanced
+		if !wasBalanced {
+			breakPatterns(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivot(data, a, b)
+		if hint == decreasingHint {
+			reverseRange(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// Check if the slice is likely already sorted
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if partialInsertionSort(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !data.Less(a-1, pivot) {
+			mid := partitionEqual(data, a, b, pivot)
+			_, ks = splitRanks(ks, mid-1)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partition(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		left, right := splitRanks(ks, mid)
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+
+		// Recurse into the shorter side and iterate on the longer one.
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqselectMany(data, a, mid, left, limit)
+			a, ks = mid+1, right
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqselectMany(data, mid+1, b, right, limit)
+			b, ks = mid, left
+		}
+	}
+}
+
+func pdqselectManyOrdered[T cmp.Ordered](data []T, a, b int, ks []int, limit int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true
+		wasPartitioned = true
+	)
+
+	for len(ks) > 0 {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSortOrdered(data, a, b)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSortOrdered(data, a, b)
+			return
+		}
+
+		// Break patterns if the last partitioning was imbalanced
+		if !wasBalanced {
+			breakPatternsOrdered(data, a, b)
+			limit--
+		}
+
+		pivot, hint := choosePivotOrdered(data, a, b)
+		if hint == decreasingHint {
+			reverseRangeOrdered(data, a, b)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// Check if the slice is likely already sorted
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if partialInsertionSortOrdered(data, a, b) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && data[a-1] >= data[pivot] {
+			mid := partitionEqualOrdered(data, a, b, pivot)
+			_, ks = splitRanks(ks, mid-1)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partitionOrdered(data, a, b, pivot)
+		wasPartitioned = alreadyPartitioned
+
+		left, right := splitRanks(ks, mid)
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+
+		// Recurse into the shorter side and iterate on the longer one.
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqselectManyOrdered(data, a, mid, left, limit)
+			a, ks = mid+1, right
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqselectManyOrdered(data, mid+1, b, right, limit)
+			b, ks = mid, left
+		}
+	}
+}
+
+func pdqselectManyFunc[E any](data []E, a, b int, ks []int, limit int, cmp func(a, b E) int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true
+		wasPartitioned = true
+	)
+
+	for len(ks) > 0 {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSortCmpFunc(data, a, b, cmp)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSortCmpFunc(data, a, b, cmp)
+			return
+		}
+
+		// Break patterns if the last partitioning was imbalanced
+		if !wasBalanced {
+			breakPatternsCmpFunc(data, a, b, cmp)
+			limit--
+		}
+
+		pivot, hint := choosePivotCmpFunc(data, a, b, cmp)
+		if hint == decreasingHint {
+			reverseRangeCmpFunc(data, a, b, cmp)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// Check if the slice is likely already sorted
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if partialInsertionSortCmpFunc(data, a, b, cmp) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && cmp(data[a-1], data[pivot]) >= 0 {
+			mid := partitionEqualCmpFunc(data, a, b, pivot, cmp)
+			_, ks = splitRanks(ks, mid-1)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partitionCmpFunc(data, a, b, pivot, cmp)
+		wasPartitioned = alreadyPartitioned
+
+		left, right := splitRanks(ks, mid)
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+
+		// Recurse into the shorter side and iterate on the longer one.
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqselectManyFunc(data, a, mid, left, limit, cmp)
+			a, ks = mid+1, right
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqselectManyFunc(data, mid+1, b, right, limit, cmp)
+			b, ks = mid, left
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package pdqselect
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSelectMany(t *testing.T) {
+	now := time.Now().UnixNano()
+	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
+
+	for _, dist := range []string{
+		"random",
+		"sorted",
+		"reversed",
+		"mostly_sorted",
+		"organ_pipe",
+		"sawtooth",
+		"push_front",
+		"push_middle",
+		"zipf",
+	} {
+		for _, size := range []int{1, 10, 100, 1000} {
+			input := generateSlice(rng, size, dist)
+
+			for _, ks := range [][]int{
+				{},
+				{1},
+				{size},
+				{1, size},
+				{size / 2, size/2 + 1},
+				{size / 2, size * 9 / 10, size * 99 / 100, size * 999 / 1000},
+				{0, 1, 1, 2, size, size + 1},
+				randomRanks(rng, size, 10),
+			} {
+				name := fmt.Sprintf("%s/n=%d/ks=%v", dist, size, ks)
+
+				t.Run("SelectMany/"+name, func(t *testing.T) {
+					testSelectMany(t, input, ks, func(data []int, ks []int) {
+						SelectMany(sort.IntSlice(data), ks)
+					})
+				})
+
+				t.Run("OrderedMany/"+name, func(t *testing.T) {
+					testSelectMany(t, input, ks, func(data []int, ks []int) {
+						OrderedMany(data, ks)
+					})
+				})
+
+				t.Run("FuncMany/"+name, func(t *testing.T) {
+					testSelectMany(t, input, ks, func(data []int, ks []int) {
+						FuncMany(data, ks, cmp.Compare)
+					})
+				})
+			}
+		}
+	}
+}
+
+func randomRanks(rng *rand.Rand, n, m int) []int {
+	ks := make([]int, m)
+	for i := range ks {
+		ks[i] = 1 + rng.IntN(n)
+	}
+	slices.Sort(ks)
+	return ks
+}
+
+func testSelectMany(t *testing.T, input, ks []int, selectMany func([]int, []int)) {
+	t.Helper()
+
+	sorted := slices.Clone(input)
+	slices.Sort(sorted)
+
+	output := slices.Clone(input)
+	selectMany(output, ks)
+
+	n := len(output)
+	for _, k := range ks {
+		if k < 1 || k > n {
+			continue
+		}
+
+		if output[k-1] != sorted[k-1] {
+			t.Fatalf("k=%d: element (%d) does not match sorted input (%d)\ninput:  %v\nsorted: %v\noutput: %v",
+				k, output[k-1], sorted[k-1], input, sorted, output)
+		}
+
+		for i := 0; i < k-1; i++ {
+			if output[i] > output[k-1] {
+				t.Fatalf("k=%d: element at index %d (%d) is larger than k-th element (%d)\noutput: %v",
+					k, i, output[i], output[k-1], output)
+			}
+		}
+
+		for i := k; i < n; i++ {
+			if output[i] < output[k-1] {
+				t.Fatalf("k=%d: element at index %d (%d) is smaller than k-th element (%d)\noutput: %v",
+					k, i, output[i], output[k-1], output)
+			}
+		}
+	}
+
+	slices.Sort(output)
+	if !slices.Equal(output, sorted) {
+		t.Fatalf("output is not a permutation of the input\ninput:  %v\noutput: %v", input, output)
+	}
+}
+
+func BenchmarkSelectMany(b *testing.B) {
+	rng := rand.New(rand.NewPCG(42, 42)) // Static seed for consistent benchmarks
+	const n = 1e6
+	ks := []int{n * 50 / 100, n * 90 / 100, n * 99 / 100, n * 999 / 1000}
+
+	for _, dist := range []string{"random", "sorted", "reversed", "mostly_sorted"} {
+		data := generateSlice(rng, n, dist)
+
+		b.Run("fn=Ordered/"+dist, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				dataCopy := slices.Clone(data)
+				for _, k := range ks {
+					Ordered(dataCopy, k)
+				}
+			}
+		})
+
+		b.Run("fn=OrderedMany/"+dist, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				dataCopy := slices.Clone(data)
+				OrderedMany(dataCopy, ks)
+			}
+		})
+	}
+}
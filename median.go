@@ -0,0 +1,139 @@
+package pdqselect
+
+import (
+	"cmp"
+	"math/bits"
+)
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Median returns the two middle elements of the data, which are the same
+// element when the length of the data is odd. On return, they occupy the
+// indices (n-1)/2 and n/2 and the data is partitioned around them, as if
+// selected with Ordered. It returns false if the data is empty.
+//
+// When the length of the data is even, both middle elements are found with a
+// single selection followed by a scan for the largest element of the lower half,
+// rather than two full selections.
+func Median[T cmp.Ordered](data []T) (lo, hi T, ok bool) {
+	n := len(data)
+	if n == 0 {
+		return lo, hi, false
+	}
+
+	mid := n / 2
+	pdqselectOrdered(data, 0, n, mid, bits.Len(uint(n)))
+	if n%2 == 1 {
+		return data[mid], data[mid], true
+	}
+
+	// data[:mid] holds the lower half, so its largest element is the lower median.
+	mx := 0
+	for i := 1; i < mid; i++ {
+		if data[i] > data[mx] {
+			mx = i
+		}
+	}
+	data[mid-1], data[mx] = data[mx], data[mid-1]
+
+	return data[mid-1], data[mid], true
+}
+
+// MedianLow returns the lower of the two middle elements of the data when its
+// length is even, and the middle element otherwise. On return, it occupies
+// index (n-1)/2. It returns false if the data is empty.
+func MedianLow[T cmp.Ordered](data []T) (T, bool) {
+	n := len(data)
+	if n == 0 {
+		var zero T
+		return zero, false
+	}
+	mid := (n - 1) / 2
+	pdqselectOrdered(data, 0, n, mid, bits.Len(uint(n)))
+	return data[mid], true
+}
+
+// MedianHigh returns the higher of the two middle elements of the data when its
+// length is even, and the middle element otherwise. On return, it occupies
+// index n/2. It returns false if the data is empty.
+func MedianHigh[T cmp.Ordered](data []T) (T, bool) {
+	n := len(data)
+	if n == 0 {
+		var zero T
+		return zero, false
+	}
+	mid := n / 2
+	pdqselectOrdered(data, 0, n, mid, bits.Len(uint(n)))
+	return data[mid], true
+}
+
+// MedianMean returns the median of the data as a float64, averaging the two
+// middle elements when the length of the data is even. The average is
+// computed in floating point, so it can't overflow for integer types.
+// It returns false if the data is empty.
+func MedianMean[T Number](data []T) (float64, bool) {
+	lo, hi, ok := Median(data)
+	if !ok {
+		return 0, false
+	}
+	if lo == hi {
+		return float64(lo), true
+	}
+	return float64(lo)/2 + float64(hi)/2, true
+}
+
+// MedianFunc is a generic version of Median that allows the caller to provide
+// a custom comparison function to determine the order of elements.
+func MedianFunc[E any](data []E, cmp func(a, b E) int) (lo, hi E, ok bool) {
+	n := len(data)
+	if n == 0 {
+		return lo, hi, false
+	}
+
+	mid := n / 2
+	floydRivestCmpFunc(data, 0, n, mid, cmp)
+	if n%2 == 1 {
+		return data[mid], data[mid], true
+	}
+
+	mx := 0
+	for i := 1; i < mid; i++ {
+		if cmp(data[i], data[mx]) > 0 {
+			mx = i
+		}
+	}
+	data[mid-1], data[mx] = data[mx], data[mid-1]
+
+	return data[mid-1], data[mid], true
+}
+
+// MedianLowFunc is a generic version of MedianLow that allows the caller to provide
+// a custom comparison function to determine the order of elements.
+func MedianLowFunc[E any](data []E, cmp func(a, b E) int) (E, bool) {
+	n := len(data)
+	if n == 0 {
+		var zero E
+		return zero, false
+	}
+	mid := (n - 1) / 2
+	floydRivestCmpFunc(data, 0, n, mid, cmp)
+	return data[mid], true
+}
+
+// MedianHighFunc is a generic version of MedianHigh that allows the caller to provide
+// a custom comparison function to determine the order of elements.
+func MedianHighFunc[E any](data []E, cmp func(a, b E) int) (E, bool) {
+	n := len(data)
+	if n == 0 {
+		var zero E
+		return zero, false
+	}
+	mid := n / 2
+	floydRivestCmpFunc(data, 0, n, mid, cmp)
+	return data[mid], true
+}
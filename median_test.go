@@ -0,0 +1,107 @@
+package pdqselect
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMedian(t *testing.T) {
+	testCases := []struct {
+		name   string
+		input  []int
+		lo, hi int
+		mean   float64
+		ok     bool
+	}{
+		{"Empty", []int{}, 0, 0, 0, false},
+		{"Single element", []int{42}, 42, 42, 42, true},
+		{"Two elements", []int{2, 1}, 1, 2, 1.5, true},
+		{"Odd", []int{5, 1, 4, 2, 3}, 3, 3, 3, true},
+		{"Even", []int{6, 1, 5, 2, 4, 3}, 3, 4, 3.5, true},
+		{"All equal", []int{7, 7, 7, 7}, 7, 7, 7, true},
+		{"Overflow", []int{math.MaxInt, math.MaxInt}, math.MaxInt, math.MaxInt, math.MaxInt, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			check := func(name string, lo, hi int, ok bool) {
+				t.Helper()
+				if lo != tc.lo || hi != tc.hi || ok != tc.ok {
+					t.Errorf("%s(%v) = %d, %d, %t; want %d, %d, %t", name, tc.input, lo, hi, ok, tc.lo, tc.hi, tc.ok)
+				}
+			}
+
+			lo, hi, ok := Median(slices.Clone(tc.input))
+			check("Median", lo, hi, ok)
+
+			lo, hi, ok = MedianFunc(slices.Clone(tc.input), cmp.Compare)
+			check("MedianFunc", lo, hi, ok)
+
+			lo, ok = MedianLow(slices.Clone(tc.input))
+			check("MedianLow", lo, tc.hi, ok)
+
+			lo, ok = MedianLowFunc(slices.Clone(tc.input), cmp.Compare)
+			check("MedianLowFunc", lo, tc.hi, ok)
+
+			hi, ok = MedianHigh(slices.Clone(tc.input))
+			check("MedianHigh", tc.lo, hi, ok)
+
+			hi, ok = MedianHighFunc(slices.Clone(tc.input), cmp.Compare)
+			check("MedianHighFunc", tc.lo, hi, ok)
+
+			if mean, ok := MedianMean(slices.Clone(tc.input)); mean != tc.mean || ok != tc.ok {
+				t.Errorf("MedianMean(%v) = %v, %t; want %v, %t", tc.input, mean, ok, tc.mean, tc.ok)
+			}
+		})
+	}
+
+	t.Run("Small integer types", func(t *testing.T) {
+		if mean, _ := MedianMean([]int8{127, 127, -128, 127}); mean != 127 {
+			t.Errorf("MedianMean = %v; want 127", mean)
+		}
+		if mean, _ := MedianMean([]uint8{255, 254}); mean != 254.5 {
+			t.Errorf("MedianMean = %v; want 254.5", mean)
+		}
+	})
+}
+
+func TestMedianPartitioning(t *testing.T) {
+	now := time.Now().UnixNano()
+	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
+
+	for _, dist := range []string{"random", "sorted", "reversed", "organ_pipe", "sawtooth", "zipf"} {
+		for _, size := range []int{1, 2, 11, 100, 1001, floydRivestCutoff + 1} {
+			input := generateSlice(rng, size, dist)
+			sorted := slices.Clone(input)
+			slices.Sort(sorted)
+
+			for name, median := range map[string]func([]int) (int, int, bool){
+				"Median":     Median[int],
+				"MedianFunc": func(data []int) (int, int, bool) { return MedianFunc(data, cmp.Compare) },
+			} {
+				t.Run(fmt.Sprintf("%s/%s/n=%d", name, dist, size), func(t *testing.T) {
+					output := slices.Clone(input)
+					lo, hi, _ := median(output)
+
+					n := len(output)
+					if lo != sorted[(n-1)/2] || hi != sorted[n/2] {
+						t.Fatalf("got medians %d, %d; want %d, %d", lo, hi, sorted[(n-1)/2], sorted[n/2])
+					}
+					if output[(n-1)/2] != lo || output[n/2] != hi {
+						t.Fatalf("medians aren't in their final positions\noutput: %v", output)
+					}
+					for i, v := range output {
+						if (i < (n-1)/2 && v > lo) || (i > n/2 && v < hi) {
+							t.Fatalf("element at index %d (%d) isn't partitioned around %d, %d\noutput: %v", i, v, lo, hi, output)
+						}
+					}
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,7 @@
+package pdqselect
+
+// heapSelectMax is the rank, counted from the start of the range, below which
+// the pdqselect loops fall back to heap select rather than to median of
+// medians. Heap select takes O(n log k) time, which for such small k is less
+// than the constant factor of median of medians.
+const heapSelectMax = 32
@@ -0,0 +1,228 @@
+package pdqselect
+
+import (
+	"cmp"
+	"fmt"
+	"math/bits"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestMedianOfMedians(t *testing.T) {
+	now := time.Now().UnixNano()
+	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
+
+	for _, dist := range distributions {
+		for _, size := range []int{1, 5, 13, 100, 1000, 10000} {
+			input := generateSlice(rng, size, dist)
+			// Leave some elements on both sides of the range to check they're untouched.
+			padded := slices.Concat([]int{-1, -1}, input, []int{-1})
+
+			for _, k := range []int{1, 2, heapSelectMax, heapSelectMax + 1, size / 2, size - 1, size} {
+				if k < 1 || k > size {
+					continue
+				}
+				name := fmt.Sprintf("%s/n=%d/k=%d", dist, size, k)
+
+				t.Run("medianOfMedians/"+name, func(t *testing.T) {
+					testSelect(t, padded, 2, size+2, k, "medianOfMedians", func(slice []int, a, b, k int) {
+						medianOfMedians(sort.IntSlice(slice), a, b, a+k-1)
+					})
+				})
+
+				t.Run("medianOfMediansOrdered/"+name, func(t *testing.T) {
+					testSelect(t, padded, 2, size+2, k, "medianOfMediansOrdered", func(slice []int, a, b, k int) {
+						medianOfMediansOrdered(slice, a, b, a+k-1)
+					})
+				})
+
+				t.Run("medianOfMediansCmpFunc/"+name, func(t *testing.T) {
+					testSelect(t, padded, 2, size+2, k, "medianOfMediansCmpFunc", func(slice []int, a, b, k int) {
+						medianOfMediansCmpFunc(slice, a, b, a+k-1, cmp.Compare)
+					})
+				})
+			}
+		}
+	}
+}
+
+// TestMedianOfMediansLinear checks that the fallback of the pdqselect loops,
+// which they start with when limit is 0, takes a linear number of comparisons
+// for any k. Heap select alone takes about n log k, which exceeds the bound for
+// large k. TestSelectAdversary checks that the fallback is reached.
+func TestMedianOfMediansLinear(t *testing.T) {
+	rng := rand.New(rand.NewPCG(42, 42))
+
+	for _, dist := range distributions {
+		for _, size := range []int{1e4, 1e5} {
+			input := generateSlice(rng, size, dist)
+
+			for _, k := range []int{1, heapSelectMax, size / 4, size / 2, size} {
+				t.Run(fmt.Sprintf("%s/n=%d/k=%d", dist, size, k), func(t *testing.T) {
+					comparisons := 0
+					pdqselectCmpFunc(slices.Clone(input), 0, size, k-1, 0, func(a, b int) int {
+						comparisons++
+						return cmp.Compare(a, b)
+					})
+					if max := 12 * size; comparisons > max {
+						t.Fatalf("made %d comparisons, want at most %d", comparisons, max)
+					}
+				})
+			}
+		}
+	}
+}
+
+// TestSelectAdversary runs the functions built on the pdqselect loops against
+// McIlroy's adversary for quicksort, which decides the order of elements as
+// they're compared so that every pivot is as bad as possible. Without a linear
+// fallback, the number of comparisons grows much faster than n for k near
+// either end of the data.
+func TestSelectAdversary(t *testing.T) {
+	fns := []string{"Select", "SelectT", "Func", "pdqselectCmpFunc", "FuncPtr", "ArgFunc", "Comparable", "WeightedQuantileFunc"}
+	for _, size := range []int{1e4, 1e5} {
+		for _, k := range []int{100, 100 + heapSelectMax, size / 4, size / 2, size - 100, size} {
+			for _, fn := range fns {
+				t.Run(fmt.Sprintf("%s/n=%d/k=%d", fn, size, k), func(t *testing.T) {
+					adv := newAdversary(size)
+					data := make([]int, size)
+					for i := range data {
+						data[i] = i
+					}
+
+					comparisons := 0
+					compare := func(a, b int) int {
+						comparisons++
+						return adv.compare(a, b)
+					}
+					switch fn {
+					case "Select":
+						Select(adversarySlice{data, compare}, k)
+					case "SelectT":
+						SelectT(adversarySlice{data, compare}, k)
+					case "Func":
+						Func(data, k, compare)
+					case "pdqselectCmpFunc":
+						pdqselectCmpFunc(data, 0, size, k-1, bits.Len(uint(size)), compare)
+					case "FuncPtr":
+						FuncPtr(data, k, func(a, b *int) int { return compare(*a, *b) })
+					case "ArgFunc":
+						// data holds the indices 0, 1, ..., n-1, so its indices can stand in for it.
+						data = ArgFunc(data, k, nil, compare)
+					case "Comparable":
+						elems := make([]adversaryElem, size)
+						for i, x := range data {
+							elems[i] = adversaryElem{x, compare}
+						}
+						Comparable(elems, k)
+						for i, e := range elems {
+							data[i] = e.x
+						}
+					case "WeightedQuantileFunc":
+						weights := make([]float64, size)
+						for i := range weights {
+							weights[i] = 1
+						}
+						// With unit weights, the quantile k/n is the element of rank k.
+						WeightedQuantileFunc(data, weights, float64(k)/float64(size), compare)
+					}
+					if max := 25 * size; comparisons > max {
+						t.Fatalf("made %d comparisons, want at most %d", comparisons, max)
+					}
+
+					vals := make([]int, size)
+					for i, x := range data {
+						vals[i] = adv.val[x]
+					}
+					testSelected(t, vals, k, cmp.Compare[int])
+				})
+			}
+		}
+	}
+}
+
+// adversarySlice is a sort.Interface over elements compared by an adversary.
+type adversarySlice struct {
+	data    []int
+	compare func(a, b int) int
+}
+
+func (x adversarySlice) Len() int           { return len(x.data) }
+func (x adversarySlice) Less(i, j int) bool { return x.compare(x.data[i], x.data[j]) < 0 }
+func (x adversarySlice) Swap(i, j int)      { x.data[i], x.data[j] = x.data[j], x.data[i] }
+
+// adversaryElem is an element compared by an adversary through its Compare method.
+type adversaryElem struct {
+	x       int
+	compare func(a, b int) int
+}
+
+func (e adversaryElem) Compare(o adversaryElem) int { return e.compare(e.x, o.x) }
+
+// adversary implements McIlroy's "A Killer Adversary for Quicksort". Elements
+// are indices into val and start out as gas, which compares greater than
+// anything solid. When two gas elements are compared, one of them is frozen
+// into the next smallest solid value, preferring the one that's least likely
+// to be a pivot.
+type adversary struct {
+	val       []int
+	gas       int
+	solid     int
+	candidate int
+}
+
+func newAdversary(n int) *adversary {
+	adv := &adversary{val: make([]int, n), gas: n}
+	for i := range adv.val {
+		adv.val[i] = adv.gas
+	}
+	return adv
+}
+
+func (adv *adversary) compare(a, b int) int {
+	if adv.val[a] == adv.gas && adv.val[b] == adv.gas {
+		if a == adv.candidate {
+			adv.val[a] = adv.solid
+		} else {
+			adv.val[b] = adv.solid
+		}
+		adv.solid++
+	}
+	if adv.val[a] == adv.gas {
+		adv.candidate = a
+	} else if adv.val[b] == adv.gas {
+		adv.candidate = b
+	}
+	return cmp.Compare(adv.val[a], adv.val[b])
+}
+
+func BenchmarkMedianOfMedians(b *testing.B) {
+	rng := rand.New(rand.NewPCG(42, 42)) // Static seed for consistent benchmarks
+	const n = 1e6
+
+	for _, dist := range []string{"random", "sorted", "reversed", "zipf"} {
+		data := generateSlice(rng, n, dist)
+		dataCopy := make([]int, n)
+
+		for _, k := range []int{heapSelectMax, n / 2} {
+			benchName := fmt.Sprintf("n=%d/k=%d/%s", int(n), k, dist)
+
+			b.Run("fn=heapSelectOrdered/"+benchName, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					copy(dataCopy, data)
+					heapSelectOrdered(dataCopy, 0, n, k-1)
+				}
+			})
+
+			b.Run("fn=medianOfMediansOrdered/"+benchName, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					copy(dataCopy, data)
+					medianOfMediansOrdered(dataCopy, 0, n, k-1)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package pdqselect
+
+import (
+	"cmp"
+	"math/bits"
+	"sort"
+)
+
+// PartialSort swaps elements in the data provided so that the first k elements
+// are the smallest k elements in the data, in sorted order. The remaining
+// elements are left in no particular order.
+//
+// It selects the k-th smallest element first and then sorts only the elements
+// before it, so it runs in O(n + k log k) time instead of the O(n log n) of
+// sorting the whole data.
+func PartialSort(data sort.Interface, k int) {
+	n := data.Len()
+	if k < 1 || k > n {
+		return
+	}
+	pdqselect(data, 0, n, k-1, bits.Len(uint(n)))
+	pdqsort(data, 0, k-1, bits.Len(uint(k-1)))
+}
+
+// PartialSortOrdered is a specialized version of PartialSort that works with slices of
+// ordered types (i.e. types that implement the cmp.Ordered interface).
+func PartialSortOrdered[T cmp.Ordered](data []T, k int) {
+	n := len(data)
+	if k < 1 || k > n {
+		return
+	}
+	pdqselectOrdered(data, 0, n, k-1, bits.Len(uint(n)))
+	pdqsortOrdered(data, 0, k-1, bits.Len(uint(k-1)))
+}
+
+// PartialSortFunc is a generic version of PartialSort that allows the caller to provide
+// a custom comparison function to determine the order of elements.
+func PartialSortFunc[E any](data []E, k int, cmp func(a, b E) int) {
+	n := len(data)
+	if k < 1 || k > n {
+		return
+	}
+	floydRivestCmpFunc(data, 0, n, k-1, cmp)
+	pdqsortCmpFunc(data, 0, k-1, bits.Len(uint(k-1)), cmp)
+}
@@ -0,0 +1,71 @@
+package pdqselect
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestPartialSort(t *testing.T) {
+	now := time.Now().UnixNano()
+	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
+
+	for _, dist := range []string{"random", "sorted", "reversed", "mostly_sorted", "organ_pipe", "push_middle", "zipf"} {
+		for _, size := range []int{1, 10, 100, 1000, floydRivestCutoff} {
+			input := generateSlice(rng, size, dist)
+
+			for _, k := range []int{0, 1, 2, size / 2, size - 1, size, size + 1} {
+				name := fmt.Sprintf("%s/n=%d/k=%d", dist, size, k)
+
+				t.Run("PartialSort/"+name, func(t *testing.T) {
+					testPartialSort(t, input, k, func(data []int) {
+						PartialSort(sort.IntSlice(data), k)
+					})
+				})
+
+				t.Run("PartialSortOrdered/"+name, func(t *testing.T) {
+					testPartialSort(t, input, k, func(data []int) {
+						PartialSortOrdered(data, k)
+					})
+				})
+
+				t.Run("PartialSortFunc/"+name, func(t *testing.T) {
+					testPartialSort(t, input, k, func(data []int) {
+						PartialSortFunc(data, k, cmp.Compare)
+					})
+				})
+			}
+		}
+	}
+}
+
+func testPartialSort(t *testing.T, input []int, k int, partialSort func([]int)) {
+	t.Helper()
+
+	output := slices.Clone(input)
+	partialSort(output)
+
+	if k < 1 || k > len(input) {
+		if !slices.Equal(output, input) {
+			t.Fatalf("k=%d: out of range k modified the data\ninput:  %v\noutput: %v", k, input, output)
+		}
+		return
+	}
+
+	sorted := slices.Clone(input)
+	slices.Sort(sorted)
+
+	if !slices.Equal(output[:k], sorted[:k]) {
+		t.Fatalf("k=%d: first k elements are not the sorted smallest k\nwant: %v\ngot:  %v", k, sorted[:k], output[:k])
+	}
+
+	rest := slices.Clone(output[k:])
+	slices.Sort(rest)
+	if !slices.Equal(rest, sorted[k:]) {
+		t.Fatalf("k=%d: remaining elements don't match\nwant: %v\ngot:  %v", k, sorted[k:], rest)
+	}
+}
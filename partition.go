@@ -0,0 +1,128 @@
+package pdqselect
+
+import (
+	"cmp"
+	"sort"
+)
+
+// Partition swaps elements in the data provided so that it's split in three
+// regions around the element at index pivot: data[:lt] holds the elements less
+// than it, data[lt:le] the elements equal to it, including the pivot itself,
+// and data[le:] the elements greater than it. So lt is the number of elements
+// less than the pivot and le the number of elements less than or equal to it.
+// Neither region is sorted.
+//
+// Partition runs in O(n) time. If pivot isn't a valid index, it does nothing
+// and returns 0, 0.
+func Partition(data sort.Interface, pivot int) (lt, le int) {
+	n := data.Len()
+	if pivot < 0 || pivot >= n {
+		return 0, 0
+	}
+	lt, _ = partition(data, 0, n, pivot)
+	le = partitionEqual(data, lt, n, lt)
+	return lt, le
+}
+
+// PartitionOrdered is like Partition, but it splits the data around a value
+// rather than an element, which doesn't need to be in the data. It's meant for
+// thresholds, like splitting latencies into those under, at, and over a limit.
+// Elements are ordered as by cmp.Less, so NaNs are less than any other value.
+func PartitionOrdered[T cmp.Ordered](data []T, pivot T) (lt, le int) {
+	lt = partitionValueOrdered(data, pivot)
+	le = lt + partitionEqualValueOrdered(data[lt:], pivot)
+	return lt, le
+}
+
+// PartitionFunc is a generic version of PartitionOrdered that allows the caller
+// to provide a custom comparison function to determine the order of elements.
+func PartitionFunc[E any](data []E, pivot E, cmp func(a, b E) int) (lt, le int) {
+	lt = partitionValueCmpFunc(data, pivot, cmp)
+	le = lt + partitionEqualValueCmpFunc(data[lt:], pivot, cmp)
+	return lt, le
+}
+
+// partitionValueOrdered moves the elements of data less than pivot to its front
+// and returns their count, like partitionOrdered does for a pivot element.
+func partitionValueOrdered[T cmp.Ordered](data []T, pivot T) int {
+	i, j := 0, len(data)-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && cmp.Less(data[i], pivot) {
+			i++
+		}
+		for i <= j && !cmp.Less(data[j], pivot) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partitionEqualValueOrdered moves the elements of data not greater than pivot
+// to its front and returns their count, like partitionEqualOrdered does for a
+// pivot element. If no element is less than pivot, those are the equal ones.
+func partitionEqualValueOrdered[T cmp.Ordered](data []T, pivot T) int {
+	i, j := 0, len(data)-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !cmp.Less(pivot, data[i]) {
+			i++
+		}
+		for i <= j && cmp.Less(pivot, data[j]) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+func partitionValueCmpFunc[E any](data []E, pivot E, cmp func(a, b E) int) int {
+	i, j := 0, len(data)-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && cmp(data[i], pivot) < 0 {
+			i++
+		}
+		for i <= j && cmp(data[j], pivot) >= 0 {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+func partitionEqualValueCmpFunc[E any](data []E, pivot E, cmp func(a, b E) int) int {
+	i, j := 0, len(data)-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && cmp(pivot, data[i]) >= 0 {
+			i++
+		}
+		for i <= j && cmp(pivot, data[j]) < 0 {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
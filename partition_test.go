@@ -0,0 +1,111 @@
+package pdqselect
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestPartition(t *testing.T) {
+	now := time.Now().UnixNano()
+	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
+
+	for _, dist := range []string{"random", "sorted", "reversed", "mostly_sorted", "organ_pipe", "sawtooth", "push_front", "zipf"} {
+		for _, size := range []int{0, 1, 10, 100, 1000} {
+			input := generateSlice(rng, size, dist)
+			for i := range input {
+				input[i] %= 50 // Force some duplicates
+			}
+
+			for _, pivot := range []int{-1, 0, 25, 49, 50} {
+				name := fmt.Sprintf("%s/n=%d/pivot=%d", dist, size, pivot)
+
+				t.Run("PartitionOrdered/"+name, func(t *testing.T) {
+					testPartition(t, input, pivot, func(data []int) (int, int) {
+						return PartitionOrdered(data, pivot)
+					})
+				})
+
+				t.Run("PartitionFunc/"+name, func(t *testing.T) {
+					testPartition(t, input, pivot, func(data []int) (int, int) {
+						return PartitionFunc(data, pivot, cmp.Compare)
+					})
+				})
+			}
+
+			for _, i := range []int{0, size / 2, size - 1} {
+				if i < 0 || i >= size {
+					continue
+				}
+				name := fmt.Sprintf("%s/n=%d/i=%d", dist, size, i)
+
+				t.Run("Partition/"+name, func(t *testing.T) {
+					testPartition(t, input, input[i], func(data []int) (int, int) {
+						return Partition(sort.IntSlice(data), i)
+					})
+				})
+			}
+		}
+	}
+
+	// An invalid pivot index leaves the data untouched.
+	for _, i := range []int{-1, 3} {
+		data := []int{3, 1, 2}
+		if lt, le := Partition(sort.IntSlice(data), i); lt != 0 || le != 0 || !slices.Equal(data, []int{3, 1, 2}) {
+			t.Errorf("Partition(i=%d) = %d, %d with data %v", i, lt, le, data)
+		}
+	}
+}
+
+func TestPartitionOrderedNaN(t *testing.T) {
+	nan := math.NaN()
+	data := []float64{2, nan, 1, 3, nan, 2, 0}
+
+	lt, le := PartitionOrdered(data, 2)
+	if lt != 4 || le != 6 {
+		t.Fatalf("got lt=%d, le=%d, want lt=4, le=6: %v", lt, le, data)
+	}
+	for i, x := range data {
+		if c := cmp.Compare(x, 2); (i < lt && c >= 0) || (i >= lt && i < le && c != 0) || (i >= le && c <= 0) {
+			t.Fatalf("element at index %d (%v) is in the wrong region: %v", i, x, data)
+		}
+	}
+}
+
+func testPartition(t *testing.T, input []int, pivot int, partition func([]int) (int, int)) {
+	t.Helper()
+
+	data := slices.Clone(input)
+	lt, le := partition(data)
+
+	wantLt, wantLe := 0, 0
+	for _, x := range input {
+		if x < pivot {
+			wantLt++
+		}
+		if x <= pivot {
+			wantLe++
+		}
+	}
+	if lt != wantLt || le != wantLe {
+		t.Fatalf("pivot=%d: got lt=%d, le=%d, want lt=%d, le=%d", pivot, lt, le, wantLt, wantLe)
+	}
+
+	for i, x := range data {
+		if (i < lt && x >= pivot) || (i >= lt && i < le && x != pivot) || (i >= le && x <= pivot) {
+			t.Fatalf("pivot=%d: element at index %d (%d) is in the wrong region (lt=%d, le=%d)\ndata: %v", pivot, i, x, lt, le, data)
+		}
+	}
+
+	sorted, got := slices.Clone(input), slices.Clone(data)
+	slices.Sort(sorted)
+	slices.Sort(got)
+	if !slices.Equal(got, sorted) {
+		t.Fatalf("pivot=%d: elements aren't a permutation of the input", pivot)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math"
+	"math/bits"
 	"math/rand/v2"
 	"slices"
 	"sort"
@@ -30,6 +31,12 @@ func TestSelect(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run("Select/"+tc.name, func(t *testing.T) {
+			testSelect(t, tc.input, 0, len(tc.input), tc.k, "Select", func(input []int, a, b, k int) {
+				Select(intSlice(input), k)
+			})
+		})
+
+		t.Run("Select/IntSlice/"+tc.name, func(t *testing.T) {
 			testSelect(t, tc.input, 0, len(tc.input), tc.k, "Select", func(input []int, a, b, k int) {
 				Select(sort.IntSlice(input), k)
 			})
@@ -63,17 +70,7 @@ func FuzzSelect(f *testing.F) {
 	now := time.Now().UnixNano()
 	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
 
-	for _, dist := range []string{
-		"random",
-		"sorted",
-		"reversed",
-		"mostly_sorted",
-		"organ_pipe",
-		"sawtooth",
-		"push_front",
-		"push_middle",
-		"zipf",
-	} {
+	for _, dist := range distributions {
 		for _, size := range []int{10, 100, 1000} {
 			data := generateSlice(rng, size, dist)
 			encodedData := encodeInts(data...)
@@ -101,6 +98,10 @@ func FuzzSelect(f *testing.F) {
 		}
 
 		testSelect(t, input, 0, len(input), int(k), "Select", func(slice []int, a, b, k int) {
+			Select(intSlice(slice), k)
+		})
+
+		testSelect(t, input, 0, len(input), int(k), "Select(sort.IntSlice)", func(slice []int, a, b, k int) {
 			Select(sort.IntSlice(slice), k)
 		})
 
@@ -120,8 +121,8 @@ func FuzzSelect(f *testing.F) {
 			pdqselectOrdered(slice, 0, len(slice), k-1, 0)
 		})
 
-		testSelect(t, input, 0, len(input), int(k), "pdqselectFunc", func(slice []int, a, b, k int) {
-			pdqselectFunc(slice, 0, len(slice), k-1, 0, cmp.Compare)
+		testSelect(t, input, 0, len(input), int(k), "pdqselectCmpFunc", func(slice []int, a, b, k int) {
+			pdqselectCmpFunc(slice, 0, len(slice), k-1, 0, cmp.Compare)
 		})
 
 		// Ensure a, b, and k are within bounds
@@ -147,12 +148,149 @@ func FuzzSelect(f *testing.F) {
 			heapSelectOrdered(slice, a, b, k-1)
 		})
 
-		testSelect(t, input, int(a), int(b), int(k), "heapSelectFunc", func(slice []int, a, b, k int) {
-			heapSelectFunc(slice, a, b, k-1, cmp.Compare)
+		testSelect(t, input, int(a), int(b), int(k), "heapSelectCmpFunc", func(slice []int, a, b, k int) {
+			heapSelectCmpFunc(slice, a, b, k-1, cmp.Compare)
+		})
+
+		testSelect(t, input, int(a), int(b), int(k), "medianOfMedians", func(slice []int, a, b, k int) {
+			medianOfMedians(sort.IntSlice(slice), a, b, a+k-1)
+		})
+
+		testSelect(t, input, int(a), int(b), int(k), "medianOfMediansOrdered", func(slice []int, a, b, k int) {
+			medianOfMediansOrdered(slice, a, b, a+k-1)
+		})
+
+		testSelect(t, input, int(a), int(b), int(k), "medianOfMediansCmpFunc", func(slice []int, a, b, k int) {
+			medianOfMediansCmpFunc(slice, a, b, a+k-1, cmp.Compare)
+		})
+
+		testSelect(t, input, int(a), int(b), int(k), "SelectIn", func(slice []int, a, b, k int) {
+			SelectIn(sort.IntSlice(slice), a, b, k)
 		})
+
+		for _, limit := range []int{0, bits.Len(uint(n))} {
+			testSelect(t, input, int(a), int(b), int(k), fmt.Sprintf("pdqselect(limit=%d)", limit), func(slice []int, a, b, k int) {
+				pdqselect(sort.IntSlice(slice), a, b, a+k-1, limit)
+			})
+
+			testSelect(t, input, int(a), int(b), int(k), fmt.Sprintf("pdqselectOrdered(limit=%d)", limit), func(slice []int, a, b, k int) {
+				pdqselectOrdered(slice, a, b, a+k-1, limit)
+			})
+
+			testSelect(t, input, int(a), int(b), int(k), fmt.Sprintf("pdqselectCmpFunc(limit=%d)", limit), func(slice []int, a, b, k int) {
+				pdqselectCmpFunc(slice, a, b, a+k-1, limit, cmp.Compare)
+			})
+
+			testSelect(t, input, int(a), int(b), int(k), fmt.Sprintf("pdqselectT(limit=%d)", limit), func(slice []int, a, b, k int) {
+				pdqselectT(sort.IntSlice(slice), a, b, a+k-1, limit)
+			})
+
+			testSelect(t, input, int(a), int(b), int(k), fmt.Sprintf("pdqselectComparable(limit=%d)", limit), func(slice []int, a, b, k int) {
+				times := unixTimes(slice)
+				pdqselectComparable(times, a, b, a+k-1, limit)
+				for i, t := range times {
+					slice[i] = int(t.Unix())
+				}
+			})
+
+			testSelect(t, input, int(a), int(b), int(k), fmt.Sprintf("pdqselectPtrCmpFunc(limit=%d)", limit), func(slice []int, a, b, k int) {
+				records := records(slice)
+				pdqselectPtrCmpFunc(records, a, b, a+k-1, limit, compareRecords)
+				for i, r := range records {
+					slice[i] = r.key
+				}
+			})
+
+			// The index variants leave the values alone, so apply the permutation
+			// of the indices to them afterwards.
+			testSelect(t, input, int(a), int(b), int(k), fmt.Sprintf("pdqselectArgOrdered(limit=%d)", limit), func(slice []int, a, b, k int) {
+				idx := identity(nil, len(slice))
+				pdqselectArgOrdered(idx, a, b, a+k-1, limit, slice)
+				vals := slices.Clone(slice)
+				for i, j := range idx {
+					slice[i] = vals[j]
+				}
+			})
+
+			testSelect(t, input, int(a), int(b), int(k), fmt.Sprintf("pdqselectArgCmpFunc(limit=%d)", limit), func(slice []int, a, b, k int) {
+				idx := identity(nil, len(slice))
+				pdqselectArgCmpFunc(idx, a, b, a+k-1, limit, slice, cmp.Compare)
+				vals := slices.Clone(slice)
+				for i, j := range idx {
+					slice[i] = vals[j]
+				}
+			})
+
+			// The stable variants keep ties in order, which testStableSelect checks
+			// on the range on its own.
+			t.Run(fmt.Sprintf("stableSelect(limit=%d)", limit), func(t *testing.T) {
+				testStableSelect(t, input[a:b], int(k), func(data []keyPos) {
+					stableSelect(byKey(data), 0, len(data), int(k)-1, limit)
+				})
+			})
+
+			t.Run(fmt.Sprintf("stableSelectCmpFunc(limit=%d)", limit), func(t *testing.T) {
+				testStableSelect(t, input[a:b], int(k), func(data []keyPos) {
+					stableSelectCmpFunc(data, 0, len(data), int(k)-1, limit, func(a, b keyPos) int { return cmp.Compare(a.key, b.key) })
+				})
+			})
+		}
 	})
 }
 
+// intSlice is like sort.IntSlice, but Select doesn't recognize it, so it calls
+// its Less and Swap methods like those of any other sort.Interface.
+type intSlice []int
+
+func (x intSlice) Len() int           { return len(x) }
+func (x intSlice) Less(i, j int) bool { return x[i] < x[j] }
+func (x intSlice) Swap(i, j int)      { x[i], x[j] = x[j], x[i] }
+
+// selectCase is an input to select the element of rank k from, named for a subtest.
+type selectCase struct {
+	name  string
+	input []int
+	k     int
+}
+
+// selectCases returns the cases the tests of the selection functions share: an
+// input of each of the distributions for each size in {1, 10, 100, 1000}, with
+// the ranks 1, 2, n/10, n/3, n/2, n-1 and n. The inputs come from a time-seeded
+// source, so that every run tries different ones.
+func selectCases() []selectCase {
+	return generateSelectCases(false)
+}
+
+// selectCasesOutOfRange is like selectCases, but it adds the ranks 0 and n+1,
+// which the selection functions must ignore.
+func selectCasesOutOfRange() []selectCase {
+	return generateSelectCases(true)
+}
+
+func generateSelectCases(outOfRange bool) []selectCase {
+	now := time.Now().UnixNano()
+	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
+
+	var cases []selectCase
+	for _, dist := range distributions {
+		for _, n := range []int{1, 10, 100, 1000} {
+			input := generateSlice(rng, n, dist)
+
+			ks := slices.DeleteFunc([]int{1, 2, n / 10, n / 3, n / 2, n - 1, n}, func(k int) bool {
+				return k < 1 || k > n
+			})
+			if outOfRange {
+				ks = append(ks, 0, n+1)
+			}
+			slices.Sort(ks)
+			for _, k := range slices.Compact(ks) {
+				cases = append(cases, selectCase{fmt.Sprintf("%s/n=%d/k=%d", dist, n, k), input, k})
+			}
+		}
+	}
+	return cases
+}
+
 func encodeInts(ints ...int) []byte {
 	buf := make([]byte, len(ints)*4)
 	for i, v := range ints {
@@ -184,6 +322,14 @@ func testSelect(t *testing.T, input []int, a, b, k int, name string, selectFunc
 	// Run pdqselect
 	selectFunc(output, a, b, k)
 
+	// Assert that the elements outside of the range weren't touched
+	for i := range output {
+		if (i < a || i >= b) && output[i] != input[i] {
+			t.Errorf("%s(a=%d, b=%d, k=%d, n=%d): element at index %d (%d) outside of the range was modified (%d)\ninput:  %v\noutput: %v",
+				name, a, b, k, b-a, i, input[i], output[i], input, output)
+		}
+	}
+
 	// Assert that the kth element is the expected one
 	if output[a+k-1] != sorted[a+k-1] {
 		t.Errorf("%s(a=%d, b=%d, k=%d, n=%d): k-th element (%d) does not match sorted input (%d)\ninput:  %v\nsorted: %v\noutput: %v",
@@ -217,6 +363,38 @@ func testSelect(t *testing.T, input []int, a, b, k int, name string, selectFunc
 	}
 }
 
+func TestSelectIn(t *testing.T) {
+	now := time.Now().UnixNano()
+	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
+
+	for _, dist := range []string{"random", "sorted", "reversed", "mostly_sorted", "organ_pipe", "sawtooth", "push_front", "push_middle", "zipf"} {
+		for _, size := range []int{10, 100, 1000} {
+			input := generateSlice(rng, size, dist)
+
+			for _, window := range [][2]int{{0, size}, {1, size}, {0, size - 1}, {size / 3, 2 * size / 3}, {size / 2, size}} {
+				a, b := window[0], window[1]
+				for _, k := range []int{1, 2, (b - a) / 2, b - a - 1, b - a} {
+					name := fmt.Sprintf("%s/n=%d/a=%d/b=%d/k=%d", dist, size, a, b, k)
+					t.Run("SelectIn/"+name, func(t *testing.T) {
+						testSelect(t, input, a, b, k, "SelectIn", func(slice []int, a, b, k int) {
+							SelectIn(sort.IntSlice(slice), a, b, k)
+						})
+					})
+				}
+			}
+		}
+	}
+
+	// Out of range arguments leave the data untouched.
+	for _, args := range [][3]int{{-1, 5, 1}, {0, 11, 1}, {5, 5, 1}, {6, 5, 1}, {2, 8, 0}, {2, 8, 7}} {
+		data := []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}
+		SelectIn(sort.IntSlice(data), args[0], args[1], args[2])
+		if !slices.Equal(data, []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}) {
+			t.Errorf("SelectIn(a=%d, b=%d, k=%d) modified the data: %v", args[0], args[1], args[2], data)
+		}
+	}
+}
+
 func BenchmarkSelect(b *testing.B) {
 	rng := rand.New(rand.NewPCG(42, 42)) // Static seed for consistent benchmarks
 	for _, n := range []int{1e6, 1e4, 100} {
@@ -266,7 +444,7 @@ func BenchmarkSelect(b *testing.B) {
 					for i := 0; i < b.N; i++ {
 						dataCopy := make([]int, len(data))
 						copy(dataCopy, data)
-						Select(sort.IntSlice(dataCopy), k)
+						Select(intSlice(dataCopy), k)
 					}
 				})
 			}
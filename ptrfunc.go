@@ -0,0 +1,13 @@
+package pdqselect
+
+// FuncPtr is a version of Func for large element types, whose comparison
+// function takes pointers to the elements rather than copies of them. That
+// saves copying two elements per comparison, which can dominate the running
+// time of Func when they're large structs. cmp must not modify the elements.
+func FuncPtr[E any](data []E, k int, cmp func(a, b *E) int) {
+	n := len(data)
+	if k < 1 || k > n {
+		return
+	}
+	floydRivestPtrCmpFunc(data, 0, n, k-1, cmp)
+}
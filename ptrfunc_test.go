@@ -0,0 +1,66 @@
+package pdqselect
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// record is a large element type, as FuncPtr is meant for.
+type record struct {
+	key     int
+	payload [24]int
+}
+
+func TestFuncPtr(t *testing.T) {
+	for _, c := range selectCases() {
+		t.Run("FuncPtr/"+c.name, func(t *testing.T) {
+			testSelect(t, c.input, 0, len(c.input), c.k, "FuncPtr", func(slice []int, a, b, k int) {
+				records := records(slice)
+				FuncPtr(records, k, compareRecords)
+				for i, r := range records {
+					slice[i] = r.key
+				}
+			})
+		})
+	}
+}
+
+func records(keys []int) []record {
+	records := make([]record, len(keys))
+	for i, key := range keys {
+		records[i].key = key
+	}
+	return records
+}
+
+func compareRecords(a, b *record) int {
+	return cmp.Compare(a.key, b.key)
+}
+
+func BenchmarkFuncPtr(b *testing.B) {
+	rng := rand.New(rand.NewPCG(42, 42)) // Static seed for consistent benchmarks
+	const n = 1e5
+
+	for _, dist := range []string{"random", "sorted", "zipf"} {
+		data := records(generateSlice(rng, n, dist))
+		k := int(n / 2)
+		benchName := fmt.Sprintf("n=%d/k=%d/%s", int(n), k, dist)
+
+		b.Run("fn=Func/"+benchName, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dataCopy := slices.Clone(data)
+				Func(dataCopy, k, func(a, b record) int { return cmp.Compare(a.key, b.key) })
+			}
+		})
+
+		b.Run("fn=FuncPtr/"+benchName, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dataCopy := slices.Clone(data)
+				FuncPtr(dataCopy, k, compareRecords)
+			}
+		})
+	}
+}
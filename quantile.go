@@ -0,0 +1,180 @@
+package pdqselect
+
+import (
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// Method is one of the nine sample quantile definitions described by Hyndman and
+// Fan in "Sample Quantiles in Statistical Packages" (1996). The value of each
+// Method is its type number in that paper, which is also the type argument of
+// R's quantile function. The names match NumPy's quantile methods.
+type Method int
+
+const (
+	// InvertedCDF is type 1: the inverse of the empirical distribution function.
+	InvertedCDF Method = iota + 1
+	// AveragedInvertedCDF is type 2: like type 1, but averaging at discontinuities.
+	AveragedInvertedCDF
+	// ClosestObservation is type 3: the nearest even order statistic.
+	ClosestObservation
+	// InterpolatedInvertedCDF is type 4: linear interpolation of the empirical CDF.
+	InterpolatedInvertedCDF
+	// Hazen is type 5: piecewise linear where the knots are the midpoints of the steps of the empirical CDF.
+	Hazen
+	// Weibull is type 6: p[k] = k / (n+1). Used by Excel's PERCENTILE.EXC and Minitab.
+	Weibull
+	// Linear is type 7: p[k] = (k-1) / (n-1). The default of R, NumPy and Excel's PERCENTILE.INC.
+	Linear
+	// MedianUnbiased is type 8: approximately median-unbiased regardless of the distribution.
+	MedianUnbiased
+	// NormalUnbiased is type 9: approximately unbiased if the data is normally distributed.
+	NormalUnbiased
+)
+
+// Quantile returns the q-th quantile of the data, with q in [0, 1], using the
+// Linear method. See QuantileWith for details.
+func Quantile[T Number](data []T, q float64) float64 {
+	return QuantileWith(data, Linear, q)
+}
+
+// QuantileWith returns the q-th quantile of the data, with q in [0, 1], as
+// defined by the given Method. It returns NaN if the data is empty, if q is
+// outside of [0, 1] or if the Method is unknown.
+//
+// The order statistics the quantile is computed from are selected in place, as
+// with OrderedMany, so the data is reordered. Interpolation between them is
+// done in float64, which can't overflow for integer types.
+func QuantileWith[T Number](data []T, m Method, q float64) float64 {
+	var out [1]float64
+	quantiles(data, m, []float64{q}, out[:])
+	return out[0]
+}
+
+// Quantiles is like Quantile, but computes several quantiles of the data in a
+// single call, selecting all the order statistics they need at once.
+func Quantiles[T Number](data []T, qs ...float64) []float64 {
+	return QuantilesWith(data, Linear, qs...)
+}
+
+// QuantilesWith is like QuantileWith, but computes several quantiles of the
+// data in a single call, selecting all the order statistics they need at once.
+// The i-th result corresponds to qs[i]; qs need not be sorted.
+func QuantilesWith[T Number](data []T, m Method, qs ...float64) []float64 {
+	out := make([]float64, len(qs))
+	quantiles(data, m, qs, out)
+	return out
+}
+
+func quantiles[T Number](data []T, m Method, qs, out []float64) {
+	n := len(data)
+
+	ks := make([]int, 0, 2*len(qs))
+	for _, q := range qs {
+		j, h, ok := m.index(n, q)
+		if !ok {
+			continue
+		}
+		ks = append(ks, j)
+		if h > 0 && j < n {
+			ks = append(ks, j+1)
+		}
+	}
+	slices.Sort(ks)
+	if ks = slices.Compact(ks); len(ks) > 0 {
+		pdqselectManyOrdered(data, 0, n, ks, bits.Len(uint(n)))
+	}
+
+	for i, q := range qs {
+		j, h, ok := m.index(n, q)
+		if !ok {
+			out[i] = math.NaN()
+			continue
+		}
+		lo := float64(data[j-1])
+		if h == 0 || j == n {
+			out[i] = lo
+			continue
+		}
+		hi := float64(data[j])
+		switch {
+		case h == 1:
+			out[i] = hi
+		case lo == hi:
+			out[i] = lo
+		default:
+			out[i] = (1-h)*lo + h*hi
+		}
+	}
+}
+
+// index returns the 1-based rank j of the lower order statistic the q-th
+// quantile of n elements is computed from, and the weight h in [0, 1] of
+// the next one, following the definitions in R's quantile function.
+// The rank is clamped to [1, n].
+func (m Method) index(n int, q float64) (j int, h float64, ok bool) {
+	if n == 0 || !(q >= 0 && q <= 1) || m < InvertedCDF || m > NormalUnbiased {
+		return 0, 0, false
+	}
+
+	// Guards against floating point error when n*q should be an integer.
+	const fuzz = 4 * 0x1p-52
+
+	var nppm float64
+	switch m {
+	case InvertedCDF, AveragedInvertedCDF:
+		nppm = float64(n) * q
+	case ClosestObservation:
+		nppm = float64(n)*q - 0.5
+	default:
+		var a, b float64
+		switch m {
+		case InterpolatedInvertedCDF:
+			a, b = 0, 1
+		case Hazen:
+			a, b = 0.5, 0.5
+		case Weibull:
+			a, b = 0, 0
+		case Linear:
+			a, b = 1, 1
+		case MedianUnbiased:
+			a, b = 1.0/3, 1.0/3
+		case NormalUnbiased:
+			a, b = 3.0/8, 3.0/8
+		}
+		nppm = a + q*(float64(n)+1-a-b)
+	}
+
+	fj := math.Floor(nppm + fuzz)
+	switch m {
+	case InvertedCDF:
+		h = boolToFloat(nppm > fj)
+	case AveragedInvertedCDF:
+		h = (boolToFloat(nppm > fj) + 1) / 2
+	case ClosestObservation:
+		h = boolToFloat(nppm != fj || int(fj)%2 != 0)
+	default:
+		if h = nppm - fj; math.Abs(h) < fuzz {
+			h = 0
+		}
+	}
+
+	// Order statistics below the first and above the last one are equal to
+	// the smallest and largest elements, so there's nothing to interpolate.
+	j = int(fj)
+	switch {
+	case j < 1:
+		return 1, 0, true
+	case j >= n:
+		return n, 0, true
+	}
+	return j, h, true
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+package pdqselect
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestQuantile(t *testing.T) {
+	// Expected values match R's quantile(x, probs, type = m).
+	testCases := []struct {
+		input []float64
+		q     float64
+		want  [9]float64
+	}{
+		{[]float64{15, 1, 10, 3, 6}, 0.3, [9]float64{3, 3, 3, 2, 3, 2.6, 3.6, 2.8666666666666667, 2.9}},
+		{[]float64{4, 1, 3, 2}, 0.5, [9]float64{2, 2.5, 2, 2, 2.5, 2.5, 2.5, 2.5, 2.5}},
+		{[]float64{4, 1, 3, 2}, 0, [9]float64{1, 1, 1, 1, 1, 1, 1, 1, 1}},
+		{[]float64{4, 1, 3, 2}, 1, [9]float64{4, 4, 4, 4, 4, 4, 4, 4, 4}},
+		{[]float64{42}, 0.75, [9]float64{42, 42, 42, 42, 42, 42, 42, 42, 42}},
+		{[]float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 0.1, [9]float64{1, 1.5, 1, 1, 1.5, 1.1, 1.9, 1.3666666666666667, 1.4}},
+	}
+
+	for _, tc := range testCases {
+		for i, want := range tc.want {
+			m := Method(i + 1)
+			t.Run(fmt.Sprintf("type=%d/q=%v/%v", m, tc.q, tc.input), func(t *testing.T) {
+				got := QuantileWith(slices.Clone(tc.input), m, tc.q)
+				if math.Abs(got-want) > 1e-9 {
+					t.Errorf("QuantileWith(%v, %d, %v) = %v; want %v", tc.input, m, tc.q, got, want)
+				}
+
+				got = QuantilesWith(slices.Clone(tc.input), m, 0, tc.q, 1)[1]
+				if math.Abs(got-want) > 1e-9 {
+					t.Errorf("QuantilesWith(%v, %d, %v) = %v; want %v", tc.input, m, tc.q, got, want)
+				}
+			})
+		}
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, got := range []float64{
+			Quantile([]int{}, 0.5),
+			Quantile([]int{1, 2}, -0.1),
+			Quantile([]int{1, 2}, 1.1),
+			Quantile([]int{1, 2}, math.NaN()),
+			QuantileWith([]int{1, 2}, 0, 0.5),
+			QuantileWith([]int{1, 2}, 10, 0.5),
+		} {
+			if !math.IsNaN(got) {
+				t.Errorf("got %v; want NaN", got)
+			}
+		}
+	})
+
+	t.Run("Overflow", func(t *testing.T) {
+		if got := Quantile([]int8{127, -128, 127, 127}, 0.5); got != 127 {
+			t.Errorf("got %v; want 127", got)
+		}
+		if got := Quantile([]uint64{math.MaxUint64, 0}, 0.5); got != math.MaxUint64/2 {
+			t.Errorf("got %v; want %v", got, float64(math.MaxUint64/2))
+		}
+		if got := Quantile([]float64{-math.MaxFloat64, math.MaxFloat64}, 0.5); got != 0 {
+			t.Errorf("got %v; want 0", got)
+		}
+	})
+}
+
+func TestQuantiles(t *testing.T) {
+	now := time.Now().UnixNano()
+	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
+
+	for _, dist := range []string{"random", "sorted", "reversed", "sawtooth", "zipf"} {
+		for _, size := range []int{1, 10, 100, 1000} {
+			input := generateSlice(rng, size, dist)
+			sorted := slices.Clone(input)
+			slices.Sort(sorted)
+
+			qs := []float64{0.99, 0, 0.5, 0.25, 1, 0.999, 0.5}
+			for m := InvertedCDF; m <= NormalUnbiased; m++ {
+				t.Run(fmt.Sprintf("%s/n=%d/type=%d", dist, size, m), func(t *testing.T) {
+					got := QuantilesWith(slices.Clone(input), m, qs...)
+					for i, q := range qs {
+						if want := QuantileWith(slices.Clone(sorted), m, q); got[i] != want {
+							t.Errorf("q=%v: got %v; want %v", q, got[i], want)
+						}
+					}
+				})
+			}
+		}
+	}
+}
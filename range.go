@@ -0,0 +1,59 @@
+package pdqselect
+
+import (
+	"cmp"
+	"math/bits"
+	"sort"
+)
+
+// SelectRange swaps elements in the data provided so that the elements
+// occupying indices lo, lo+1, ..., hi-1 are the ones that would occupy them if
+// the data were sorted. All elements before lo are smaller or equal, and all
+// elements after hi-1 are larger or equal to the ones in that window.
+//
+// If sorted is true, the window itself is also sorted; otherwise it's left in
+// no particular order other than data[lo] and data[hi-1] being its smallest and
+// largest elements.
+//
+// Both ends of the window are selected in a single pass that shares partitioning
+// work, as in SelectMany. Nothing is done if the window isn't a valid, non-empty
+// range of the data.
+func SelectRange(data sort.Interface, lo, hi int, sorted bool) {
+	n := data.Len()
+	if lo < 0 || hi > n || lo >= hi {
+		return
+	}
+	ks := [2]int{lo + 1, hi}
+	pdqselectMany(data, 0, n, ks[:], bits.Len(uint(n)))
+	if sorted && hi-lo > 2 {
+		pdqsort(data, lo+1, hi-1, bits.Len(uint(hi-lo-2)))
+	}
+}
+
+// OrderedRange is a specialized version of SelectRange that works with slices of
+// ordered types (i.e. types that implement the cmp.Ordered interface).
+func OrderedRange[T cmp.Ordered](data []T, lo, hi int, sorted bool) {
+	n := len(data)
+	if lo < 0 || hi > n || lo >= hi {
+		return
+	}
+	ks := [2]int{lo + 1, hi}
+	pdqselectManyOrdered(data, 0, n, ks[:], bits.Len(uint(n)))
+	if sorted && hi-lo > 2 {
+		pdqsortOrdered(data, lo+1, hi-1, bits.Len(uint(hi-lo-2)))
+	}
+}
+
+// FuncRange is a generic version of SelectRange that allows the caller to provide
+// a custom comparison function to determine the order of elements.
+func FuncRange[E any](data []E, lo, hi int, sorted bool, cmp func(a, b E) int) {
+	n := len(data)
+	if lo < 0 || hi > n || lo >= hi {
+		return
+	}
+	ks := [2]int{lo + 1, hi}
+	pdqselectManyFunc(data, 0, n, ks[:], bits.Len(uint(n)), cmp)
+	if sorted && hi-lo > 2 {
+		pdqsortCmpFunc(data, lo+1, hi-1, bits.Len(uint(hi-lo-2)), cmp)
+	}
+}
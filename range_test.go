@@ -0,0 +1,96 @@
+package pdqselect
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSelectRange(t *testing.T) {
+	now := time.Now().UnixNano()
+	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
+
+	for _, dist := range []string{"random", "sorted", "reversed", "mostly_sorted", "sawtooth", "zipf"} {
+		for _, size := range []int{1, 10, 100, 1000} {
+			input := generateSlice(rng, size, dist)
+
+			for _, window := range [][2]int{
+				{0, size},
+				{0, 1},
+				{size - 1, size},
+				{size / 2, size/2 + 1},
+				{size / 4, size * 3 / 4},
+				{size / 10, size/10 + 5},
+				{-1, size},
+				{0, size + 1},
+				{size / 2, size / 2},
+			} {
+				lo, hi := window[0], window[1]
+				for _, sorted := range []bool{false, true} {
+					name := fmt.Sprintf("%s/n=%d/lo=%d/hi=%d/sorted=%t", dist, size, lo, hi, sorted)
+
+					t.Run("SelectRange/"+name, func(t *testing.T) {
+						testSelectRange(t, input, lo, hi, sorted, func(data []int) {
+							SelectRange(sort.IntSlice(data), lo, hi, sorted)
+						})
+					})
+
+					t.Run("OrderedRange/"+name, func(t *testing.T) {
+						testSelectRange(t, input, lo, hi, sorted, func(data []int) {
+							OrderedRange(data, lo, hi, sorted)
+						})
+					})
+
+					t.Run("FuncRange/"+name, func(t *testing.T) {
+						testSelectRange(t, input, lo, hi, sorted, func(data []int) {
+							FuncRange(data, lo, hi, sorted, cmp.Compare)
+						})
+					})
+				}
+			}
+		}
+	}
+}
+
+func testSelectRange(t *testing.T, input []int, lo, hi int, sorted bool, selectRange func([]int)) {
+	t.Helper()
+
+	output := slices.Clone(input)
+	selectRange(output)
+
+	if lo < 0 || hi > len(input) || lo >= hi {
+		if !slices.Equal(output, input) {
+			t.Fatalf("invalid window modified the data\ninput:  %v\noutput: %v", input, output)
+		}
+		return
+	}
+
+	want := slices.Clone(input)
+	slices.Sort(want)
+
+	window := slices.Clone(output[lo:hi])
+	if sorted && !slices.Equal(window, want[lo:hi]) {
+		t.Fatalf("window is not sorted\nwant: %v\ngot:  %v", want[lo:hi], window)
+	}
+
+	slices.Sort(window)
+	if !slices.Equal(window, want[lo:hi]) {
+		t.Fatalf("window doesn't hold the expected elements\nwant: %v\ngot:  %v", want[lo:hi], window)
+	}
+
+	for i := 0; i < lo; i++ {
+		if output[i] > window[0] {
+			t.Fatalf("element at index %d (%d) is larger than the window minimum (%d)\noutput: %v", i, output[i], window[0], output)
+		}
+	}
+
+	for i := hi; i < len(output); i++ {
+		if output[i] < window[len(window)-1] {
+			t.Fatalf("element at index %d (%d) is smaller than the window maximum (%d)\noutput: %v", i, output[i], window[len(window)-1], output)
+		}
+	}
+}
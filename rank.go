@@ -0,0 +1,79 @@
+package pdqselect
+
+import (
+	"cmp"
+	"math/bits"
+	"sort"
+)
+
+// Span describes where the k-th smallest element and its duplicates ended up
+// after a selection.
+type Span struct {
+	// Index is the index of the k-th smallest element, i.e. k-1.
+	Index int
+	// Lo and Hi delimit the half-open range data[Lo:Hi] holding every element
+	// equal to the k-th smallest one. Lo <= Index < Hi.
+	Lo, Hi int
+}
+
+// Rank is a Span that also carries the value of the k-th smallest element.
+type Rank[T any] struct {
+	Span
+	Value T
+}
+
+// SelectRank is like Select, but it also gathers all the elements equal to the
+// k-th smallest one into a contiguous range around index k-1 and reports where
+// that range lies. This makes it possible to find the ties of the k-th element,
+// e.g. for top-k with ties or deduplication, without another scan.
+//
+// It returns false without modifying the data if k is out of range.
+//
+// Gathering the duplicates takes two additional partitioning passes over the
+// data on either side of index k-1, so SelectRank still runs in O(n) time.
+func SelectRank(data sort.Interface, k int) (Span, bool) {
+	n := data.Len()
+	if k < 1 || k > n {
+		return Span{}, false
+	}
+	pdqselect(data, 0, n, k-1, bits.Len(uint(n)))
+
+	// All elements in data[:k] are smaller or equal to the k-th one, so
+	// partitioning them moves the equal ones to the end of that range.
+	lo, _ := partition(data, 0, k, k-1)
+	// All elements in data[k-1:] are larger or equal to the k-th one, so
+	// partitioning them moves the equal ones to the start of that range.
+	hi := partitionEqual(data, k-1, n, k-1)
+
+	return Span{Index: k - 1, Lo: lo, Hi: hi}, true
+}
+
+// OrderedRank is a specialized version of SelectRank that works with slices of
+// ordered types (i.e. types that implement the cmp.Ordered interface).
+func OrderedRank[T cmp.Ordered](data []T, k int) (Rank[T], bool) {
+	n := len(data)
+	if k < 1 || k > n {
+		return Rank[T]{}, false
+	}
+	pdqselectOrdered(data, 0, n, k-1, bits.Len(uint(n)))
+
+	lo, _ := partitionOrdered(data, 0, k, k-1)
+	hi := partitionEqualOrdered(data, k-1, n, k-1)
+
+	return Rank[T]{Span: Span{Index: k - 1, Lo: lo, Hi: hi}, Value: data[k-1]}, true
+}
+
+// FuncRank is a generic version of SelectRank that allows the caller to provide
+// a custom comparison function to determine the order of elements.
+func FuncRank[E any](data []E, k int, cmp func(a, b E) int) (Rank[E], bool) {
+	n := len(data)
+	if k < 1 || k > n {
+		return Rank[E]{}, false
+	}
+	floydRivestCmpFunc(data, 0, n, k-1, cmp)
+
+	lo, _ := partitionCmpFunc(data, 0, k, k-1, cmp)
+	hi := partitionEqualCmpFunc(data, k-1, n, k-1, cmp)
+
+	return Rank[E]{Span: Span{Index: k - 1, Lo: lo, Hi: hi}, Value: data[k-1]}, true
+}
@@ -0,0 +1,92 @@
+package pdqselect
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSelectRank(t *testing.T) {
+	now := time.Now().UnixNano()
+	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
+
+	for _, dist := range []string{"random", "sorted", "reversed", "sawtooth", "push_front", "push_middle", "zipf"} {
+		for _, size := range []int{1, 10, 100, 1000, floydRivestCutoff} {
+			input := generateSlice(rng, size, dist)
+
+			for _, k := range []int{0, 1, size / 3, size / 2, size, size + 1} {
+				name := fmt.Sprintf("%s/n=%d/k=%d", dist, size, k)
+
+				t.Run("SelectRank/"+name, func(t *testing.T) {
+					testSelectRank(t, input, k, func(data []int) (Span, bool) {
+						return SelectRank(sort.IntSlice(data), k)
+					})
+				})
+
+				t.Run("OrderedRank/"+name, func(t *testing.T) {
+					testSelectRank(t, input, k, func(data []int) (Span, bool) {
+						r, ok := OrderedRank(data, k)
+						if ok && r.Value != data[r.Index] {
+							t.Errorf("Value (%d) doesn't match data[Index] (%d)", r.Value, data[r.Index])
+						}
+						return r.Span, ok
+					})
+				})
+
+				t.Run("FuncRank/"+name, func(t *testing.T) {
+					testSelectRank(t, input, k, func(data []int) (Span, bool) {
+						r, ok := FuncRank(data, k, cmp.Compare)
+						if ok && r.Value != data[r.Index] {
+							t.Errorf("Value (%d) doesn't match data[Index] (%d)", r.Value, data[r.Index])
+						}
+						return r.Span, ok
+					})
+				})
+			}
+		}
+	}
+}
+
+func testSelectRank(t *testing.T, input []int, k int, selectRank func([]int) (Span, bool)) {
+	t.Helper()
+
+	output := slices.Clone(input)
+	span, ok := selectRank(output)
+
+	if k < 1 || k > len(input) {
+		if ok {
+			t.Fatalf("k=%d: expected out of range k to return false", k)
+		}
+		if !slices.Equal(output, input) {
+			t.Fatalf("k=%d: out of range k modified the data\ninput:  %v\noutput: %v", k, input, output)
+		}
+		return
+	}
+
+	if !ok {
+		t.Fatalf("k=%d: expected in range k to return true", k)
+	}
+
+	sorted := slices.Clone(input)
+	slices.Sort(sorted)
+	want := sorted[k-1]
+
+	lo, _ := slices.BinarySearch(sorted, want)
+	hi, _ := slices.BinarySearch(sorted, want+1)
+	if span != (Span{Index: k - 1, Lo: lo, Hi: hi}) {
+		t.Fatalf("k=%d: got span %+v, want %+v", k, span, Span{Index: k - 1, Lo: lo, Hi: hi})
+	}
+
+	for i, v := range output {
+		switch {
+		case i < lo && v >= want,
+			i >= lo && i < hi && v != want,
+			i >= hi && v <= want:
+			t.Fatalf("k=%d: element at index %d (%d) is misplaced relative to %d in span %+v\noutput: %v", k, i, v, want, span, output)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package pdqselect
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// SelectT is a generic version of Select over the type of the data rather than
+// the sort.Interface it implements. Select stores the data in an interface,
+// which allocates for data that isn't a pointer, like sort.IntSlice, and calls
+// Less and Swap through it. SelectT takes the data as is, so it doesn't
+// allocate. It still calls the methods indirectly, since instantiations of
+// generic functions share code between types of the same shape, so apart from
+// the allocation it's about as fast as Select.
+//
+// Like Select, SelectT works on the underlying slice as fast as Ordered does
+// when data is a sort.IntSlice, sort.Float64Slice or sort.StringSlice. It
+// doesn't recognize OrderedSlicer, which would make it allocate; pass those
+// to Select.
+func SelectT[D sort.Interface](data D, k int) {
+	n := data.Len()
+	if k < 1 || k > n {
+		return
+	}
+	if selectSortSlice(data, n, k) {
+		return
+	}
+	pdqselectT(data, 0, n, k-1, bits.Len(uint(n)))
+}
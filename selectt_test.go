@@ -0,0 +1,91 @@
+package pdqselect
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestSelectT(t *testing.T) {
+	for _, c := range selectCases() {
+		t.Run("SelectT/"+c.name, func(t *testing.T) {
+			testSelect(t, c.input, 0, len(c.input), c.k, "SelectT", func(slice []int, a, b, k int) {
+				SelectT(intSlice(slice), k)
+			})
+		})
+
+		t.Run("SelectT/IntSlice/"+c.name, func(t *testing.T) {
+			testSelect(t, c.input, 0, len(c.input), c.k, "SelectT", func(slice []int, a, b, k int) {
+				SelectT(sort.IntSlice(slice), k)
+			})
+		})
+	}
+}
+
+func TestSelectTAllocs(t *testing.T) {
+	rng := rand.New(rand.NewPCG(42, 42))
+	data := generateSlice(rng, 1000, "random")
+
+	allocs := testing.AllocsPerRun(10, func() {
+		SelectT(intSlice(data), 100)
+	})
+	if allocs != 0 {
+		t.Errorf("SelectT allocated %v times", allocs)
+	}
+
+	allocs = testing.AllocsPerRun(10, func() {
+		SelectT(sort.IntSlice(data), 100)
+	})
+	if allocs != 0 {
+		t.Errorf("SelectT allocated %v times with a sort.IntSlice", allocs)
+	}
+}
+
+func BenchmarkSelectT(b *testing.B) {
+	rng := rand.New(rand.NewPCG(42, 42)) // Static seed for consistent benchmarks
+	const n = 1e5
+
+	for _, dist := range []string{"random", "sorted", "zipf"} {
+		data := generateSlice(rng, n, dist)
+		k := int(n / 2)
+		benchName := fmt.Sprintf("n=%d/k=%d/%s", int(n), k, dist)
+
+		b.Run("fn=Select/"+benchName, func(b *testing.B) {
+			b.ReportAllocs()
+			dataCopy := make([]int, len(data))
+			for i := 0; i < b.N; i++ {
+				copy(dataCopy, data)
+				Select(intSlice(dataCopy), k)
+			}
+		})
+
+		b.Run("fn=SelectT/"+benchName, func(b *testing.B) {
+			b.ReportAllocs()
+			dataCopy := make([]int, len(data))
+			for i := 0; i < b.N; i++ {
+				copy(dataCopy, data)
+				SelectT(intSlice(dataCopy), k)
+			}
+		})
+
+		b.Run("fn=SelectT/IntSlice/"+benchName, func(b *testing.B) {
+			b.ReportAllocs()
+			dataCopy := make([]int, len(data))
+			for i := 0; i < b.N; i++ {
+				copy(dataCopy, data)
+				SelectT(sort.IntSlice(dataCopy), k)
+			}
+		})
+
+		b.Run("fn=Ordered/"+benchName, func(b *testing.B) {
+			b.ReportAllocs()
+			dataCopy := slices.Clone(data)
+			for i := 0; i < b.N; i++ {
+				copy(dataCopy, data)
+				Ordered(dataCopy, k)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package pdqselect
+
+import (
+	"math/bits"
+	"reflect"
+)
+
+// SelectFn is like Select, but for data of length n that's accessed through a
+// pair of callbacks rather than a sort.Interface: less reports whether the
+// element at index i is less than the one at index j, and swap swaps them.
+// It's meant for data that's neither a slice nor a type of its own, like the
+// columns of a structure-of-arrays table that must be swapped together.
+func SelectFn(n int, less func(i, j int) bool, swap func(i, j int), k int) {
+	if k < 1 || k > n {
+		return
+	}
+	pdqselect(lessSwap{n, less, swap}, 0, n, k-1, bits.Len(uint(n)))
+}
+
+// Slice is like SelectFn for the slice x, mirroring sort.Slice: less reports
+// whether x[i] is less than x[j], and elements are swapped with reflect.Swapper,
+// so code that calls sort.Slice only to take the first k elements of x can call
+// Slice instead.
+//
+// Slice panics if x isn't a slice.
+func Slice(x any, k int, less func(i, j int) bool) {
+	swap := reflect.Swapper(x)
+	SelectFn(reflect.ValueOf(x).Len(), less, swap, k)
+}
+
+// lessSwap is the sort.Interface of the data accessed through less and swap.
+type lessSwap struct {
+	n    int
+	less func(i, j int) bool
+	swap func(i, j int)
+}
+
+func (x lessSwap) Len() int           { return x.n }
+func (x lessSwap) Less(i, j int) bool { return x.less(i, j) }
+func (x lessSwap) Swap(i, j int)      { x.swap(i, j) }
@@ -0,0 +1,63 @@
+package pdqselect
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+	"time"
+)
+
+func TestSelectFn(t *testing.T) {
+	now := time.Now().UnixNano()
+	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
+
+	for _, dist := range []string{"random", "sorted", "reversed", "mostly_sorted", "organ_pipe", "sawtooth", "push_front", "zipf"} {
+		for _, size := range []int{1, 10, 100, 1000} {
+			input := generateSlice(rng, size, dist)
+
+			for _, k := range []int{1, 2, size / 2, size - 1, size} {
+				if k < 1 || k > size {
+					continue
+				}
+				name := fmt.Sprintf("%s/n=%d/k=%d", dist, size, k)
+
+				t.Run("SelectFn/"+name, func(t *testing.T) {
+					testSelect(t, input, 0, size, k, "SelectFn", func(slice []int, a, b, k int) {
+						// Keep a parallel column that must move along with the data.
+						pos := make([]int, len(slice))
+						for i := range pos {
+							pos[i] = i
+						}
+						SelectFn(len(slice), func(i, j int) bool {
+							return slice[i] < slice[j]
+						}, func(i, j int) {
+							slice[i], slice[j] = slice[j], slice[i]
+							pos[i], pos[j] = pos[j], pos[i]
+						}, k)
+
+						for i, p := range pos {
+							if slice[i] != input[p] {
+								t.Fatalf("k=%d: column wasn't swapped along with the data at index %d", k, i)
+							}
+						}
+					})
+				})
+
+				t.Run("Slice/"+name, func(t *testing.T) {
+					testSelect(t, input, 0, size, k, "Slice", func(slice []int, a, b, k int) {
+						Slice(slice, k, func(i, j int) bool { return slice[i] < slice[j] })
+					})
+				})
+			}
+		}
+	}
+}
+
+func TestSlicePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Slice didn't panic with a non-slice")
+		}
+	}()
+	Slice(42, 1, func(i, j int) bool { return false })
+}
@@ -0,0 +1,209 @@
+package pdqselect
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// StableSelect is like Select, but ties are broken by original position: if the
+// k-th smallest element is equal to other elements, the ones that came first in
+// the data are the ones placed among the first k. Equal elements keep their
+// relative order, so the result is reproducible regardless of how ties are
+// distributed in the data.
+//
+// Stability comes at a cost: elements are moved with rotations rather than
+// swaps, so StableSelect runs in O(n log n) time on average instead of O(n),
+// and O(n log² n) in the worst case, falling back to a stable sort. It makes
+// O(n) calls to data.Less on average and needs no extra memory.
+func StableSelect(data sort.Interface, k int) {
+	n := data.Len()
+	if k < 1 || k > n {
+		return
+	}
+	stableSelect(data, 0, n, k-1, bits.Len(uint(n)))
+}
+
+// StableFunc is a generic version of StableSelect that allows the caller to provide
+// a custom comparison function to determine the order of elements.
+func StableFunc[E any](data []E, k int, cmp func(a, b E) int) {
+	n := len(data)
+	if k < 1 || k > n {
+		return
+	}
+	stableSelectCmpFunc(data, 0, n, k-1, bits.Len(uint(n)), cmp)
+}
+
+func stableSelect(data sort.Interface, a, b, k, limit int) {
+	const maxInsertion = 12
+
+	for {
+		length := b - a
+
+		// Insertion sort only swaps adjacent elements that are out of order, so it's stable.
+		if length <= maxInsertion {
+			insertionSort(data, a, b)
+			return
+		}
+
+		// Fall back to stable sort if too many bad choices were made.
+		if limit == 0 {
+			stable(subInterface{data, a, length}, length)
+			return
+		}
+
+		pivot, _ := choosePivot(data, a, b)
+
+		// Count the elements equal to the pivot that precede it, so that it can be
+		// put back among them in its original position after partitioning.
+		before := 0
+		for i := a; i < pivot; i++ {
+			if !data.Less(i, pivot) && !data.Less(pivot, i) {
+				before++
+			}
+		}
+
+		// Move the pivot to the front so that it stays in place while the rest of
+		// the elements are stably partitioned around it.
+		if pivot > a {
+			rotate(data, a, pivot, pivot+1)
+		}
+		lt := stablePartition(data, a+1, b, func(i int) bool { return data.Less(i, a) })
+		le := stablePartition(data, lt, b, func(i int) bool { return !data.Less(a, i) })
+		if lt+before > a+1 {
+			rotate(data, a, a+1, lt+before)
+		}
+		lo, hi := lt-1, le // data[lo:hi] holds the elements equal to the pivot
+
+		balanceThreshold := length / 8
+		switch {
+		case k < lo:
+			if lo-a < balanceThreshold || b-hi < balanceThreshold {
+				limit--
+			}
+			b = lo
+		case k >= hi:
+			if lo-a < balanceThreshold || b-hi < balanceThreshold {
+				limit--
+			}
+			a = hi
+		default:
+			return
+		}
+	}
+}
+
+func stableSelectCmpFunc[E any](data []E, a, b, k, limit int, cmp func(a, b E) int) {
+	const maxInsertion = 12
+
+	for {
+		length := b - a
+
+		// Insertion sort only swaps adjacent elements that are out of order, so it's stable.
+		if length <= maxInsertion {
+			insertionSortCmpFunc(data, a, b, cmp)
+			return
+		}
+
+		// Fall back to stable sort if too many bad choices were made.
+		if limit == 0 {
+			stableCmpFunc(data[a:b], length, cmp)
+			return
+		}
+
+		pivot, _ := choosePivotCmpFunc(data, a, b, cmp)
+
+		// Count the elements equal to the pivot that precede it, so that it can be
+		// put back among them in its original position after partitioning.
+		before := 0
+		for i := a; i < pivot; i++ {
+			if cmp(data[i], data[pivot]) == 0 {
+				before++
+			}
+		}
+
+		// Move the pivot to the front so that it stays in place while the rest of
+		// the elements are stably partitioned around it.
+		if pivot > a {
+			rotateCmpFunc(data, a, pivot, pivot+1, cmp)
+		}
+		lt := stablePartitionCmpFunc(data, a+1, b, func(i int) bool { return cmp(data[i], data[a]) < 0 }, cmp)
+		le := stablePartitionCmpFunc(data, lt, b, func(i int) bool { return cmp(data[i], data[a]) == 0 }, cmp)
+		if lt+before > a+1 {
+			rotateCmpFunc(data, a, a+1, lt+before, cmp)
+		}
+		lo, hi := lt-1, le // data[lo:hi] holds the elements equal to the pivot
+
+		balanceThreshold := length / 8
+		switch {
+		case k < lo:
+			if lo-a < balanceThreshold || b-hi < balanceThreshold {
+				limit--
+			}
+			b = lo
+		case k >= hi:
+			if lo-a < balanceThreshold || b-hi < balanceThreshold {
+				limit--
+			}
+			a = hi
+		default:
+			return
+		}
+	}
+}
+
+// stablePartition reorders data[a:b] so that the elements for which pred
+// returns true precede the ones for which it returns false, preserving the
+// relative order within both groups, and returns the index of the first
+// element of the second group. pred is called with the index of each element
+// before it's moved.
+//
+// It recursively partitions both halves of the range and rotates the second
+// group of the first half with the first group of the second half, which
+// takes O(n log n) swaps.
+func stablePartition(data sort.Interface, a, b int, pred func(i int) bool) int {
+	switch b - a {
+	case 0:
+		return a
+	case 1:
+		if pred(a) {
+			return b
+		}
+		return a
+	}
+	m := int(uint(a+b) >> 1)
+	l := stablePartition(data, a, m, pred)
+	r := stablePartition(data, m, b, pred)
+	if l < m && m < r {
+		rotate(data, l, m, r)
+	}
+	return l + (r - m)
+}
+
+func stablePartitionCmpFunc[E any](data []E, a, b int, pred func(i int) bool, cmp func(a, b E) int) int {
+	switch b - a {
+	case 0:
+		return a
+	case 1:
+		if pred(a) {
+			return b
+		}
+		return a
+	}
+	m := int(uint(a+b) >> 1)
+	l := stablePartitionCmpFunc(data, a, m, pred, cmp)
+	r := stablePartitionCmpFunc(data, m, b, pred, cmp)
+	if l < m && m < r {
+		rotateCmpFunc(data, l, m, r, cmp)
+	}
+	return l + (r - m)
+}
+
+// subInterface is the sort.Interface of data[a:a+n].
+type subInterface struct {
+	data sort.Interface
+	a, n int
+}
+
+func (x subInterface) Len() int           { return x.n }
+func (x subInterface) Less(i, j int) bool { return x.data.Less(x.a+i, x.a+j) }
+func (x subInterface) Swap(i, j int)      { x.data.Swap(x.a+i, x.a+j) }
@@ -0,0 +1,123 @@
+package pdqselect
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+type keyPos struct{ key, pos int }
+
+type byKey []keyPos
+
+func (x byKey) Len() int           { return len(x) }
+func (x byKey) Less(i, j int) bool { return x[i].key < x[j].key }
+func (x byKey) Swap(i, j int)      { x[i], x[j] = x[j], x[i] }
+
+func TestStableSelect(t *testing.T) {
+	for _, c := range selectCasesOutOfRange() {
+		for _, distinct := range []int{1, 3, len(c.input)} {
+			// Reduce the number of distinct keys to force ties.
+			input := slices.Clone(c.input)
+			for i := range input {
+				input[i] %= max(distinct, 1)
+			}
+			name := fmt.Sprintf("%s/distinct=%d", c.name, distinct)
+
+			t.Run("StableSelect/"+name, func(t *testing.T) {
+				testStableSelect(t, input, c.k, func(data []keyPos) {
+					StableSelect(byKey(data), c.k)
+				})
+			})
+
+			t.Run("StableFunc/"+name, func(t *testing.T) {
+				testStableSelect(t, input, c.k, func(data []keyPos) {
+					StableFunc(data, c.k, func(a, b keyPos) int { return cmp.Compare(a.key, b.key) })
+				})
+			})
+		}
+	}
+}
+
+func testStableSelect(t *testing.T, input []int, k int, stableSelect func([]keyPos)) {
+	t.Helper()
+
+	data := make([]keyPos, len(input))
+	for i, key := range input {
+		data[i] = keyPos{key, i}
+	}
+	stableSelect(data)
+
+	if k < 1 || k > len(input) {
+		for i, x := range data {
+			if x.pos != i {
+				t.Fatalf("k=%d: data was modified for out of range k", k)
+			}
+		}
+		return
+	}
+
+	// A stable sort of the input gives the expected order of ties.
+	sorted := make([]keyPos, len(input))
+	for i, key := range input {
+		sorted[i] = keyPos{key, i}
+	}
+	slices.SortStableFunc(sorted, func(a, b keyPos) int { return cmp.Compare(a.key, b.key) })
+
+	if data[k-1] != sorted[k-1] {
+		t.Fatalf("k=%d: k-th element %v does not match stably sorted input %v", k, data[k-1], sorted[k-1])
+	}
+
+	less := func(a, b keyPos) int { return cmp.Or(cmp.Compare(a.key, b.key), cmp.Compare(a.pos, b.pos)) }
+	got := slices.Clone(data[:k])
+	slices.SortFunc(got, less)
+	if !slices.Equal(got, sorted[:k]) {
+		t.Fatalf("k=%d: got first k elements %v, want %v", k, got, sorted[:k])
+	}
+
+	// Equal elements must keep their relative order on both sides of k.
+	for _, part := range [][]keyPos{data[:k], data[k:]} {
+		last := map[int]int{}
+		for _, x := range part {
+			if p, ok := last[x.key]; ok && p > x.pos {
+				t.Fatalf("k=%d: element %v was moved before an equal element at position %d", k, x, p)
+			}
+			last[x.key] = x.pos
+		}
+	}
+}
+
+func BenchmarkStableFunc(b *testing.B) {
+	rng := rand.New(rand.NewPCG(42, 42)) // Static seed for consistent benchmarks
+	const n = 1e5
+	compare := func(a, b int) int { return cmp.Compare(a, b) }
+
+	for _, dist := range []string{"random", "sorted", "reversed", "zipf"} {
+		data := generateSlice(rng, n, dist)
+		k := int(n / 2)
+		benchName := fmt.Sprintf("n=%d/k=%d/%s", int(n), k, dist)
+
+		b.Run("fn=Func/"+benchName, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dataCopy := slices.Clone(data)
+				Func(dataCopy, k, compare)
+			}
+		})
+
+		b.Run("fn=StableFunc/"+benchName, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dataCopy := slices.Clone(data)
+				StableFunc(dataCopy, k, compare)
+			}
+		})
+
+		b.Run("fn=SortStableFunc/"+benchName, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				dataCopy := slices.Clone(data)
+				slices.SortStableFunc(dataCopy, compare)
+			}
+		})
+	}
+}
@@ -0,0 +1,332 @@
+package pdqselect
+
+import (
+	"cmp"
+	"math"
+	"math/bits"
+)
+
+// WeightedQuantile returns the weighted q-th quantile of the values, with q in
+// [0, 1]: the smallest value x such that the total weight of the values smaller
+// or equal to x is at least q times the total weight of all values.
+//
+// weights[i] is the weight of values[i]. Both slices are reordered together, so
+// that on return the quantile is in its sorted position and the values before
+// and after it are smaller or equal and larger or equal, respectively.
+//
+// It returns false if the slices are empty or of different lengths, if q is
+// outside of [0, 1], or if any weight is negative or NaN, or they're all zero.
+//
+// The weights on each side of every partition are summed as selection proceeds,
+// so it runs in O(n) time instead of sorting, in the worst case as well as on
+// average.
+func WeightedQuantile[T cmp.Ordered](values []T, weights []float64, q float64) (T, bool) {
+	var zero T
+	target, ok := weightedTarget(weights, len(values), q)
+	if !ok {
+		return zero, false
+	}
+	n := len(values)
+	i := pdqselectWeightedOrdered(values, weights, 0, n, target, bits.Len(uint(n)))
+	return values[i], true
+}
+
+// WeightedMedian returns the weighted median of the values.
+// It's equivalent to WeightedQuantile(values, weights, 0.5).
+func WeightedMedian[T cmp.Ordered](values []T, weights []float64) (T, bool) {
+	return WeightedQuantile(values, weights, 0.5)
+}
+
+// WeightedQuantileFunc is a generic version of WeightedQuantile that allows the caller to provide
+// a custom comparison function to determine the order of elements.
+func WeightedQuantileFunc[E any](values []E, weights []float64, q float64, cmp func(a, b E) int) (E, bool) {
+	var zero E
+	target, ok := weightedTarget(weights, len(values), q)
+	if !ok {
+		return zero, false
+	}
+	n := len(values)
+	i := pdqselectWeightedFunc(values, weights, 0, n, target, bits.Len(uint(n)), cmp)
+	return values[i], true
+}
+
+// WeightedMedianFunc is a generic version of WeightedMedian that allows the caller to provide
+// a custom comparison function to determine the order of elements.
+func WeightedMedianFunc[E any](values []E, weights []float64, cmp func(a, b E) int) (E, bool) {
+	return WeightedQuantileFunc(values, weights, 0.5, cmp)
+}
+
+// weightedTarget validates the arguments of the weighted quantile functions and
+// returns the cumulative weight the quantile of n values must reach.
+func weightedTarget(weights []float64, n int, q float64) (float64, bool) {
+	if n == 0 || n != len(weights) || !(q >= 0 && q <= 1) {
+		return 0, false
+	}
+	var total float64
+	for _, w := range weights {
+		if !(w >= 0) {
+			return 0, false
+		}
+		total += w
+	}
+	if total == 0 || math.IsInf(total, 0) {
+		return 0, false
+	}
+	return q * total, true
+}
+
+// crossing returns the index in [a, b) at which the cumulative sum of
+// weights[a:b] reaches target. Rounding errors may leave it just short of
+// target, in which case b-1 is returned.
+func crossing(weights []float64, a, b int, target float64) int {
+	for i := a; i < b-1; i++ {
+		if target -= weights[i]; target <= 0 {
+			return i
+		}
+	}
+	return b - 1
+}
+
+func sum(weights []float64) (s float64) {
+	for _, w := range weights {
+		s += w
+	}
+	return s
+}
+
+// pdqselectWeightedOrdered returns the index of the element of data[a:b] at which
+// the cumulative weight in sorted order reaches target, placing it in its sorted
+// position. weights is permuted along with data.
+func pdqselectWeightedOrdered[T cmp.Ordered](data []T, weights []float64, a, b int, target float64, limit int) int {
+	if target <= 0 { // Fast path; just find the minimum and place it in a
+		mn := a
+		for i := a + 1; i < b; i++ {
+			if data[i] < data[mn] {
+				mn = i
+			}
+		}
+		data[a], data[mn] = data[mn], data[a]
+		weights[a], weights[mn] = weights[mn], weights[a]
+		return a
+	}
+
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true
+		wasPartitioned = true
+		budget         = 8 * (b - a) // Elements left to go through before falling back
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSortOrderedKV(data, a, b, weights)
+			return crossing(weights, a, b, target)
+		}
+
+		// Fall back to median of medians if too many bad choices were made, or if
+		// going through the range over and over adds up to more than linear time.
+		if limit == 0 || budget < 0 {
+			return medianOfMediansWeightedOrdered(data, weights, a, b, target)
+		}
+		budget -= length
+
+		// Break patterns if the last partitioning was imbalanced
+		if !wasBalanced {
+			breakPatternsOrderedKV(data, a, b, weights)
+			limit--
+		}
+
+		pivot, hint := choosePivotOrderedKV(data, a, b, weights)
+		if hint == decreasingHint {
+			reverseRangeOrderedKV(data, a, b, weights)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// Check if the slice is likely already sorted
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if partialInsertionSortOrderedKV(data, a, b, weights) {
+				return crossing(weights, a, b, target)
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && data[a-1] >= data[pivot] {
+			mid := partitionEqualOrderedKV(data, a, b, pivot, weights)
+			equal := sum(weights[a:mid])
+			if target <= equal {
+				return a
+			}
+			target -= equal
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partitionOrderedKV(data, a, b, pivot, weights)
+		wasPartitioned = alreadyPartitioned
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+
+		wasBalanced = min(leftLen, rightLen) >= balanceThreshold
+
+		// Carry the weight of the elements left behind over to the next iteration.
+		left := sum(weights[a:mid])
+		switch {
+		case target <= left:
+			b = mid
+		case target <= left+weights[mid]:
+			return mid
+		default:
+			target -= left + weights[mid]
+			a = mid + 1
+		}
+	}
+}
+
+func pdqselectWeightedFunc[E any](data []E, weights []float64, a, b int, target float64, limit int, cmp func(a, b E) int) int {
+	if target <= 0 { // Fast path; just find the minimum and place it in a
+		mn := a
+		for i := a + 1; i < b; i++ {
+			if cmp(data[i], data[mn]) < 0 {
+				mn = i
+			}
+		}
+		data[a], data[mn] = data[mn], data[a]
+		weights[a], weights[mn] = weights[mn], weights[a]
+		return a
+	}
+
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true
+		wasPartitioned = true
+		budget         = 8 * (b - a) // Elements left to go through before falling back
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSortCmpFuncKV(data, a, b, weights, cmp)
+			return crossing(weights, a, b, target)
+		}
+
+		// Fall back to median of medians if too many bad choices were made, or if
+		// going through the range over and over adds up to more than linear time.
+		if limit == 0 || budget < 0 {
+			return medianOfMediansWeightedFunc(data, weights, a, b, target, cmp)
+		}
+		budget -= length
+
+		// Break patterns if the last partitioning was imbalanced
+		if !wasBalanced {
+			breakPatternsCmpFuncKV(data, a, b, weights, cmp)
+			limit--
+		}
+
+		pivot, hint := choosePivotCmpFuncKV(data, a, b, weights, cmp)
+		if hint == decreasingHint {
+			reverseRangeCmpFuncKV(data, a, b, weights, cmp)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// Check if the slice is likely already sorted
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if partialInsertionSortCmpFuncKV(data, a, b, weights, cmp) {
+				return crossing(weights, a, b, target)
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && cmp(data[a-1], data[pivot]) >= 0 {
+			mid := partitionEqualCmpFuncKV(data, a, b, pivot, weights, cmp)
+			equal := sum(weights[a:mid])
+			if target <= equal {
+				return a
+			}
+			target -= equal
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partitionCmpFuncKV(data, a, b, pivot, weights, cmp)
+		wasPartitioned = alreadyPartitioned
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+
+		wasBalanced = min(leftLen, rightLen) >= balanceThreshold
+
+		// Carry the weight of the elements left behind over to the next iteration.
+		left := sum(weights[a:mid])
+		switch {
+		case target <= left:
+			b = mid
+		case target <= left+weights[mid]:
+			return mid
+		default:
+			target -= left + weights[mid]
+			a = mid + 1
+		}
+	}
+}
+
+// medianOfMediansWeightedOrdered is the fallback of pdqselectWeightedOrdered. It
+// repeatedly partitions data[a:b] around its median, which medianOfMediansOrderedKV
+// finds in O(n) time in the worst case, and goes on with the half that holds
+// target, so it runs in O(n) time in the worst case too.
+func medianOfMediansWeightedOrdered[T cmp.Ordered](data []T, weights []float64, a, b int, target float64) int {
+	const maxInsertion = 12
+
+	for b-a > maxInsertion {
+		mid := a + (b-a)/2
+		medianOfMediansOrderedKV(data, a, b, mid, weights)
+
+		left := sum(weights[a:mid])
+		switch {
+		case target <= left:
+			b = mid
+		case target <= left+weights[mid]:
+			return mid
+		default:
+			target -= left + weights[mid]
+			a = mid + 1
+		}
+	}
+	insertionSortOrderedKV(data, a, b, weights)
+	return crossing(weights, a, b, target)
+}
+
+func medianOfMediansWeightedFunc[E any](data []E, weights []float64, a, b int, target float64, cmp func(a, b E) int) int {
+	const maxInsertion = 12
+
+	for b-a > maxInsertion {
+		mid := a + (b-a)/2
+		medianOfMediansCmpFuncKV(data, a, b, mid, weights, cmp)
+
+		left := sum(weights[a:mid])
+		switch {
+		case target <= left:
+			b = mid
+		case target <= left+weights[mid]:
+			return mid
+		default:
+			target -= left + weights[mid]
+			a = mid + 1
+		}
+	}
+	insertionSortCmpFuncKV(data, a, b, weights, cmp)
+	return crossing(weights, a, b, target)
+}
@@ -0,0 +1,130 @@
+package pdqselect
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWeightedQuantile(t *testing.T) {
+	testCases := []struct {
+		name    string
+		values  []int
+		weights []float64
+		q       float64
+		want    int
+		ok      bool
+	}{
+		{"Unweighted median", []int{3, 1, 2}, []float64{1, 1, 1}, 0.5, 2, true},
+		{"Heavy tail", []int{1, 2, 3, 4}, []float64{1, 1, 1, 10}, 0.5, 4, true},
+		{"Exact boundary", []int{4, 3, 2, 1}, []float64{1, 1, 1, 1}, 0.5, 2, true},
+		{"Zero quantile", []int{3, 1, 2}, []float64{1, 0, 1}, 0, 1, true},
+		{"Full quantile", []int{3, 1, 2}, []float64{0, 1, 1}, 1, 2, true},
+		{"Empty", []int{}, []float64{}, 0.5, 0, false},
+		{"Length mismatch", []int{1, 2}, []float64{1}, 0.5, 0, false},
+		{"Negative weight", []int{1, 2}, []float64{1, -1}, 0.5, 0, false},
+		{"NaN weight", []int{1, 2}, []float64{1, math.NaN()}, 0.5, 0, false},
+		{"Zero total weight", []int{1, 2}, []float64{0, 0}, 0.5, 0, false},
+		{"Invalid quantile", []int{1, 2}, []float64{1, 1}, 1.5, 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := WeightedQuantile(slices.Clone(tc.values), slices.Clone(tc.weights), tc.q)
+			if got != tc.want || ok != tc.ok {
+				t.Errorf("WeightedQuantile = %d, %t; want %d, %t", got, ok, tc.want, tc.ok)
+			}
+
+			got, ok = WeightedQuantileFunc(slices.Clone(tc.values), slices.Clone(tc.weights), tc.q, cmp.Compare)
+			if got != tc.want || ok != tc.ok {
+				t.Errorf("WeightedQuantileFunc = %d, %t; want %d, %t", got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
+
+func TestWeightedQuantileRandom(t *testing.T) {
+	now := time.Now().UnixNano()
+	rng := rand.New(rand.NewPCG(uint64(now), uint64(now>>32)))
+
+	for _, dist := range []string{"random", "sorted", "reversed", "mostly_sorted", "sawtooth", "push_front", "zipf"} {
+		for _, size := range []int{1, 10, 100, 1000} {
+			values := generateSlice(rng, size, dist)
+			// Small integer weights keep all the sums exact.
+			weights := make([]float64, size)
+			for i := range weights {
+				weights[i] = float64(rng.IntN(4))
+			}
+			weights[rng.IntN(size)]++
+
+			for _, q := range []float64{0, 0.01, 0.25, 0.5, 0.9, 0.99, 1} {
+				want := weightedQuantileBySorting(values, weights, q)
+
+				t.Run(fmt.Sprintf("WeightedQuantile/%s/n=%d/q=%v", dist, size, q), func(t *testing.T) {
+					testWeightedQuantile(t, values, weights, want, func(v []int, w []float64) (int, bool) {
+						return WeightedQuantile(v, w, q)
+					})
+				})
+
+				t.Run(fmt.Sprintf("WeightedQuantileFunc/%s/n=%d/q=%v", dist, size, q), func(t *testing.T) {
+					testWeightedQuantile(t, values, weights, want, func(v []int, w []float64) (int, bool) {
+						return WeightedQuantileFunc(v, w, q, cmp.Compare)
+					})
+				})
+			}
+		}
+	}
+}
+
+type weightedValue struct {
+	v int
+	w float64
+}
+
+func zipWeighted(values []int, weights []float64) []weightedValue {
+	pairs := make([]weightedValue, len(values))
+	for i := range values {
+		pairs[i] = weightedValue{values[i], weights[i]}
+	}
+	slices.SortFunc(pairs, func(a, b weightedValue) int {
+		return cmp.Or(cmp.Compare(a.v, b.v), cmp.Compare(a.w, b.w))
+	})
+	return pairs
+}
+
+func weightedQuantileBySorting(values []int, weights []float64, q float64) int {
+	pairs := zipWeighted(values, weights)
+	target := q * sum(weights)
+	var cum float64
+	for _, p := range pairs {
+		if cum += p.w; cum >= target {
+			return p.v
+		}
+	}
+	return pairs[len(pairs)-1].v
+}
+
+func testWeightedQuantile(t *testing.T, values []int, weights []float64, want int, weightedQuantile func([]int, []float64) (int, bool)) {
+	t.Helper()
+
+	v, w := slices.Clone(values), slices.Clone(weights)
+	got, ok := weightedQuantile(v, w)
+	if !ok || got != want {
+		t.Fatalf("got %d, %t; want %d, true", got, ok, want)
+	}
+
+	if !slices.Equal(zipWeighted(v, w), zipWeighted(values, weights)) {
+		t.Fatalf("values and weights weren't permuted together")
+	}
+
+	i := slices.Index(v, got)
+	for j, x := range v {
+		if (j < i && x > got) || (j > i && x < got) {
+			t.Fatalf("element at index %d (%d) isn't partitioned around the quantile %d at index %d\nvalues: %v", j, x, got, i, v)
+		}
+	}
+}
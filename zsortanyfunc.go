@@ -1,3 +1,5 @@
+// Code generated by gen_sort_variants.go; DO NOT EDIT.
+
 // Copyright 2022 The Go Authors. All rights reserved.
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
@@ -126,6 +128,181 @@ func pdqsortCmpFunc[E any](data []E, a, b, limit int, cmp func(a, b E) int) {
 	}
 }
 
+// pdqselectCmpFunc places the element of rank k of data[a:b] at index k, with
+// the smaller elements before it and the larger ones after it.
+// It's pdqsortCmpFunc recursing only into the side that holds k.
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to
+// heapSelectCmpFunc or medianOfMediansCmpFunc.
+func pdqselectCmpFunc[E any](data []E, a, b, k, limit int, cmp func(a, b E) int) {
+	if k == a { // Fast path; just find the minimum and place it in a
+		mn := a
+		for i := a + 1; i < b; i++ {
+			if cmp(data[i], data[mn]) < 0 {
+				mn = i
+			}
+		}
+		if mn != a {
+			data[a], data[mn] = data[mn], data[a]
+		}
+		return
+	}
+
+	if hi := b - 1; k == hi { // Fast path; just find the maximum and place it in b-1
+		mx := a
+		for i := a + 1; i < b; i++ {
+			if cmp(data[mx], data[i]) < 0 {
+				mx = i
+			}
+		}
+		if mx != hi {
+			data[hi], data[mx] = data[mx], data[hi]
+		}
+		return
+	}
+
+	const maxInsertion = 12
+
+	var (
+		lo             = a // Elements before lo are outside of the range being selected
+		wasBalanced    = true
+		wasPartitioned = true
+		budget         = 8 * (b - a) // Elements left to go through before falling back
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSortCmpFunc(data, a, b, cmp)
+			return
+		}
+
+		// Fall back to heap select or median of medians if too many bad choices were
+		// made, or if going through the range over and over adds up to more than
+		// linear time, which bad choices that still count as balanced can do.
+		if limit == 0 || budget < 0 {
+			if k-a < heapSelectMax {
+				heapSelectCmpFunc(data, a, b, k-a, cmp)
+			} else {
+				medianOfMediansCmpFunc(data, a, b, k, cmp)
+			}
+			return
+		}
+		budget -= length
+
+		// Break patterns if the last partitioning was imbalanced
+		if !wasBalanced {
+			breakPatternsCmpFunc(data, a, b, cmp)
+			limit--
+		}
+
+		pivot, hint := choosePivotCmpFunc(data, a, b, cmp)
+		if hint == decreasingHint {
+			reverseRangeCmpFunc(data, a, b, cmp)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// Check if the slice is likely already sorted
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if partialInsertionSortCmpFunc(data, a, b, cmp) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > lo && !(cmp(data[a-1], data[pivot]) < 0) {
+			mid := partitionEqualCmpFunc(data, a, b, pivot, cmp)
+			if k < mid {
+				return
+			}
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partitionCmpFunc(data, a, b, pivot, cmp)
+		if k == mid {
+			return
+		}
+
+		wasPartitioned = alreadyPartitioned
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+
+		wasBalanced = min(leftLen, rightLen) >= balanceThreshold
+		if k < mid {
+			b = mid
+		} else { // k > mid
+			a = mid + 1
+		}
+	}
+}
+
+// heapSelectCmpFunc places the element of rank k of data[a:b], counted from a,
+// at index a+k, with the smaller elements before it, in O(n log k) time.
+func heapSelectCmpFunc[E any](data []E, a, b, k int, cmp func(a, b E) int) {
+	n := b - a
+	hi := k + 1
+
+	// Build max-heap of first k elements
+	for i := k / 2; i >= 0; i-- {
+		siftDownCmpFunc(data, i, hi, a, cmp)
+	}
+
+	// Process remaining elements
+	for i := hi; i < n; i++ {
+		j := a + i
+		if cmp(data[j], data[a]) < 0 {
+			data[a], data[j] = data[j], data[a]
+			siftDownCmpFunc(data, 0, hi, a, cmp)
+		}
+	}
+
+	// Place the k-th element into its final place
+	data[a], data[a+k] = data[a+k], data[a]
+}
+
+// medianOfMediansCmpFunc places the element of rank k of data[a:b] at index k, with the
+// smaller elements before it and the larger ones after it, in O(n) time in the
+// worst case. It's the fallback of pdqselectCmpFunc for when too many bad pivots were
+// chosen, as heap select would take O(n log n) time for k far from a.
+//
+// The pivot is the median of the medians of groups of five elements, which
+// is selected recursively. At least 3/10 of the elements are no greater than it
+// and 3/10 no less, and elements equal to it are split off from the greater
+// ones, so each partition leaves at most 7/10 of the range to select from.
+func medianOfMediansCmpFunc[E any](data []E, a, b, k int, cmp func(a, b E) int) {
+	const maxInsertion = 12
+
+	for b-a > maxInsertion {
+		// Move the median of each group of five elements to the front.
+		m := a
+		for i := a; i+5 <= b; i += 5 {
+			insertionSortCmpFunc(data, i, i+5, cmp)
+			data[m], data[i+2] = data[i+2], data[m]
+			m++
+		}
+		pivot := a + (m-a)/2
+		medianOfMediansCmpFunc(data, a, m, pivot, cmp)
+
+		mid, _ := partitionCmpFunc(data, a, b, pivot, cmp)
+		if k < mid {
+			b = mid
+			continue
+		}
+		hi := partitionEqualCmpFunc(data, mid, b, mid, cmp) // data[mid:hi] holds the elements equal to the pivot
+		if k < hi {
+			return
+		}
+		a = hi
+	}
+	insertionSortCmpFunc(data, a, b, cmp)
+}
+
 // partitionCmpFunc does one quicksort partition.
 // Let p = data[pivot]
 // Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
@@ -213,7 +390,7 @@ func partialInsertionSortCmpFunc[E any](data []E, a, b int, cmp func(a, b E) int
 
 		// Shift the smaller one to the left.
 		if i-a >= 2 {
-			for j := i - 1; j >= 1; j-- {
+			for j := i - 1; j > a; j-- {
 				if !(cmp(data[j], data[j-1]) < 0) {
 					break
 				}
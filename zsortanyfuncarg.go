@@ -0,0 +1,654 @@
+// Code generated by gen_sort_variants.go; DO NOT EDIT.
+
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pdqselect
+
+// insertionSortArgCmpFunc sorts data[a:b] using insertion sort.
+func insertionSortArgCmpFunc[E any](data []int, a, b int, vals []E, cmp func(a, b E) int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (cmp(vals[data[j]], vals[data[j-1]]) < 0); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDownArgCmpFunc implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDownArgCmpFunc[E any](data []int, lo, hi, first int, vals []E, cmp func(a, b E) int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (cmp(vals[data[first+child]], vals[data[first+child+1]]) < 0) {
+			child++
+		}
+		if !(cmp(vals[data[first+root]], vals[data[first+child]]) < 0) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+func heapSortArgCmpFunc[E any](data []int, a, b int, vals []E, cmp func(a, b E) int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDownArgCmpFunc(data, i, hi, first, vals, cmp)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		siftDownArgCmpFunc(data, lo, i, first, vals, cmp)
+	}
+}
+
+// pdqsortArgCmpFunc sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsortArgCmpFunc[E any](data []int, a, b, limit int, vals []E, cmp func(a, b E) int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSortArgCmpFunc(data, a, b, vals, cmp)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSortArgCmpFunc(data, a, b, vals, cmp)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatternsArgCmpFunc(data, a, b, vals, cmp)
+			limit--
+		}
+
+		pivot, hint := choosePivotArgCmpFunc(data, a, b, vals, cmp)
+		if hint == decreasingHint {
+			reverseRangeArgCmpFunc(data, a, b, vals, cmp)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if partialInsertionSortArgCmpFunc(data, a, b, vals, cmp) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(cmp(vals[data[a-1]], vals[data[pivot]]) < 0) {
+			mid := partitionEqualArgCmpFunc(data, a, b, pivot, vals, cmp)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partitionArgCmpFunc(data, a, b, pivot, vals, cmp)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsortArgCmpFunc(data, a, mid, limit, vals, cmp)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsortArgCmpFunc(data, mid+1, b, limit, vals, cmp)
+			b = mid
+		}
+	}
+}
+
+// pdqselectArgCmpFunc places the element of rank k of data[a:b] at index k, with
+// the smaller elements before it and the larger ones after it.
+// It's pdqsortArgCmpFunc recursing only into the side that holds k.
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to
+// heapSelectArgCmpFunc or medianOfMediansArgCmpFunc.
+func pdqselectArgCmpFunc[E any](data []int, a, b, k, limit int, vals []E, cmp func(a, b E) int) {
+	if k == a { // Fast path; just find the minimum and place it in a
+		mn := a
+		for i := a + 1; i < b; i++ {
+			if cmp(vals[data[i]], vals[data[mn]]) < 0 {
+				mn = i
+			}
+		}
+		if mn != a {
+			data[a], data[mn] = data[mn], data[a]
+		}
+		return
+	}
+
+	if hi := b - 1; k == hi { // Fast path; just find the maximum and place it in b-1
+		mx := a
+		for i := a + 1; i < b; i++ {
+			if cmp(vals[data[mx]], vals[data[i]]) < 0 {
+				mx = i
+			}
+		}
+		if mx != hi {
+			data[hi], data[mx] = data[mx], data[hi]
+		}
+		return
+	}
+
+	const maxInsertion = 12
+
+	var (
+		lo             = a // Elements before lo are outside of the range being selected
+		wasBalanced    = true
+		wasPartitioned = true
+		budget         = 8 * (b - a) // Elements left to go through before falling back
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSortArgCmpFunc(data, a, b, vals, cmp)
+			return
+		}
+
+		// Fall back to heap select or median of medians if too many bad choices were
+		// made, or if going through the range over and over adds up to more than
+		// linear time, which bad choices that still count as balanced can do.
+		if limit == 0 || budget < 0 {
+			if k-a < heapSelectMax {
+				heapSelectArgCmpFunc(data, a, b, k-a, vals, cmp)
+			} else {
+				medianOfMediansArgCmpFunc(data, a, b, k, vals, cmp)
+			}
+			return
+		}
+		budget -= length
+
+		// Break patterns if the last partitioning was imbalanced
+		if !wasBalanced {
+			breakPatternsArgCmpFunc(data, a, b, vals, cmp)
+			limit--
+		}
+
+		pivot, hint := choosePivotArgCmpFunc(data, a, b, vals, cmp)
+		if hint == decreasingHint {
+			reverseRangeArgCmpFunc(data, a, b, vals, cmp)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// Check if the slice is likely already sorted
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if partialInsertionSortArgCmpFunc(data, a, b, vals, cmp) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > lo && !(cmp(vals[data[a-1]], vals[data[pivot]]) < 0) {
+			mid := partitionEqualArgCmpFunc(data, a, b, pivot, vals, cmp)
+			if k < mid {
+				return
+			}
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partitionArgCmpFunc(data, a, b, pivot, vals, cmp)
+		if k == mid {
+			return
+		}
+
+		wasPartitioned = alreadyPartitioned
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+
+		wasBalanced = min(leftLen, rightLen) >= balanceThreshold
+		if k < mid {
+			b = mid
+		} else { // k > mid
+			a = mid + 1
+		}
+	}
+}
+
+// heapSelectArgCmpFunc places the element of rank k of data[a:b], counted from a,
+// at index a+k, with the smaller elements before it, in O(n log k) time.
+func heapSelectArgCmpFunc[E any](data []int, a, b, k int, vals []E, cmp func(a, b E) int) {
+	n := b - a
+	hi := k + 1
+
+	// Build max-heap of first k elements
+	for i := k / 2; i >= 0; i-- {
+		siftDownArgCmpFunc(data, i, hi, a, vals, cmp)
+	}
+
+	// Process remaining elements
+	for i := hi; i < n; i++ {
+		j := a + i
+		if cmp(vals[data[j]], vals[data[a]]) < 0 {
+			data[a], data[j] = data[j], data[a]
+			siftDownArgCmpFunc(data, 0, hi, a, vals, cmp)
+		}
+	}
+
+	// Place the k-th element into its final place
+	data[a], data[a+k] = data[a+k], data[a]
+}
+
+// medianOfMediansArgCmpFunc places the element of rank k of data[a:b] at index k, with the
+// smaller elements before it and the larger ones after it, in O(n) time in the
+// worst case. It's the fallback of pdqselectArgCmpFunc for when too many bad pivots were
+// chosen, as heap select would take O(n log n) time for k far from a.
+//
+// The pivot is the median of the medians of groups of five elements, which
+// is selected recursively. At least 3/10 of the elements are no greater than it
+// and 3/10 no less, and elements equal to it are split off from the greater
+// ones, so each partition leaves at most 7/10 of the range to select from.
+func medianOfMediansArgCmpFunc[E any](data []int, a, b, k int, vals []E, cmp func(a, b E) int) {
+	const maxInsertion = 12
+
+	for b-a > maxInsertion {
+		// Move the median of each group of five elements to the front.
+		m := a
+		for i := a; i+5 <= b; i += 5 {
+			insertionSortArgCmpFunc(data, i, i+5, vals, cmp)
+			data[m], data[i+2] = data[i+2], data[m]
+			m++
+		}
+		pivot := a + (m-a)/2
+		medianOfMediansArgCmpFunc(data, a, m, pivot, vals, cmp)
+
+		mid, _ := partitionArgCmpFunc(data, a, b, pivot, vals, cmp)
+		if k < mid {
+			b = mid
+			continue
+		}
+		hi := partitionEqualArgCmpFunc(data, mid, b, mid, vals, cmp) // data[mid:hi] holds the elements equal to the pivot
+		if k < hi {
+			return
+		}
+		a = hi
+	}
+	insertionSortArgCmpFunc(data, a, b, vals, cmp)
+}
+
+// partitionArgCmpFunc does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partitionArgCmpFunc[E any](data []int, a, b, pivot int, vals []E, cmp func(a, b E) int) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (cmp(vals[data[i]], vals[data[a]]) < 0) {
+		i++
+	}
+	for i <= j && !(cmp(vals[data[j]], vals[data[a]]) < 0) {
+		j--
+	}
+	if i > j {
+		data[j], data[a] = data[a], data[j]
+		return j, true
+	}
+	data[i], data[j] = data[j], data[i]
+	i++
+	j--
+
+	for {
+		for i <= j && (cmp(vals[data[i]], vals[data[a]]) < 0) {
+			i++
+		}
+		for i <= j && !(cmp(vals[data[j]], vals[data[a]]) < 0) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[j], data[a] = data[a], data[j]
+	return j, false
+}
+
+// partitionEqualArgCmpFunc partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqualArgCmpFunc[E any](data []int, a, b, pivot int, vals []E, cmp func(a, b E) int) (newpivot int) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(cmp(vals[data[a]], vals[data[i]]) < 0) {
+			i++
+		}
+		for i <= j && (cmp(vals[data[a]], vals[data[j]]) < 0) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSortArgCmpFunc partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSortArgCmpFunc[E any](data []int, a, b int, vals []E, cmp func(a, b E) int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(cmp(vals[data[i]], vals[data[i-1]]) < 0) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1] = data[i-1], data[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j > a; j-- {
+				if !(cmp(vals[data[j]], vals[data[j-1]]) < 0) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(cmp(vals[data[j]], vals[data[j-1]]) < 0) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatternsArgCmpFunc scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatternsArgCmpFunc[E any](data []int, a, b int, vals []E, cmp func(a, b E) int) {
+	length := b - a
+	if length >= 8 {
+		random := xorshift(length)
+		modulus := nextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other] = data[a+other], data[idx]
+		}
+	}
+}
+
+// choosePivotArgCmpFunc chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivotArgCmpFunc[E any](data []int, a, b int, vals []E, cmp func(a, b E) int) (pivot int, hint sortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacentArgCmpFunc(data, i, &swaps, vals, cmp)
+			j = medianAdjacentArgCmpFunc(data, j, &swaps, vals, cmp)
+			k = medianAdjacentArgCmpFunc(data, k, &swaps, vals, cmp)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = medianArgCmpFunc(data, i, j, k, &swaps, vals, cmp)
+	}
+
+	switch swaps {
+	case 0:
+		return j, increasingHint
+	case maxSwaps:
+		return j, decreasingHint
+	default:
+		return j, unknownHint
+	}
+}
+
+// order2ArgCmpFunc returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2ArgCmpFunc[E any](data []int, a, b int, swaps *int, vals []E, cmp func(a, b E) int) (int, int) {
+	if cmp(vals[data[b]], vals[data[a]]) < 0 {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// medianArgCmpFunc returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func medianArgCmpFunc[E any](data []int, a, b, c int, swaps *int, vals []E, cmp func(a, b E) int) int {
+	a, b = order2ArgCmpFunc(data, a, b, swaps, vals, cmp)
+	b, c = order2ArgCmpFunc(data, b, c, swaps, vals, cmp)
+	a, b = order2ArgCmpFunc(data, a, b, swaps, vals, cmp)
+	return b
+}
+
+// medianAdjacentArgCmpFunc finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacentArgCmpFunc[E any](data []int, a int, swaps *int, vals []E, cmp func(a, b E) int) int {
+	return medianArgCmpFunc(data, a-1, a, a+1, swaps, vals, cmp)
+}
+
+func reverseRangeArgCmpFunc[E any](data []int, a, b int, vals []E, cmp func(a, b E) int) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
+
+func swapRangeArgCmpFunc[E any](data []int, a, b, n int, vals []E, cmp func(a, b E) int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i] = data[b+i], data[a+i]
+	}
+}
+
+func stableArgCmpFunc[E any](data []int, n int, vals []E, cmp func(a, b E) int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSortArgCmpFunc(data, a, b, vals, cmp)
+		a = b
+		b += blockSize
+	}
+	insertionSortArgCmpFunc(data, a, n, vals, cmp)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMergeArgCmpFunc(data, a, a+blockSize, b, vals, cmp)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMergeArgCmpFunc(data, a, m, n, vals, cmp)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMergeArgCmpFunc merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMergeArgCmpFunc[E any](data []int, a, m, b int, vals []E, cmp func(a, b E) int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if cmp(vals[data[h]], vals[data[a]]) < 0 {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1] = data[k+1], data[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(cmp(vals[data[m]], vals[data[h]]) < 0) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1] = data[k-1], data[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(cmp(vals[data[p-c]], vals[data[c]]) < 0) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotateArgCmpFunc(data, start, m, end, vals, cmp)
+	}
+	if a < start && start < mid {
+		symMergeArgCmpFunc(data, a, start, mid, vals, cmp)
+	}
+	if mid < end && end < b {
+		symMergeArgCmpFunc(data, mid, end, b, vals, cmp)
+	}
+}
+
+// rotateArgCmpFunc rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotateArgCmpFunc[E any](data []int, a, m, b int, vals []E, cmp func(a, b E) int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRangeArgCmpFunc(data, m-i, m, j, vals, cmp)
+			i -= j
+		} else {
+			swapRangeArgCmpFunc(data, m-i, m+j-i, i, vals, cmp)
+			j -= i
+		}
+	}
+	// i == j
+	swapRangeArgCmpFunc(data, m-i, m, i, vals, cmp)
+}
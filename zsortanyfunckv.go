@@ -0,0 +1,654 @@
+// Code generated by gen_sort_variants.go; DO NOT EDIT.
+
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pdqselect
+
+// insertionSortCmpFuncKV sorts data[a:b] using insertion sort.
+func insertionSortCmpFuncKV[K, V any](data []K, a, b int, vals []V, cmp func(a, b K) int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (cmp(data[j], data[j-1]) < 0); j-- {
+			data[j], data[j-1], vals[j], vals[j-1] = data[j-1], data[j], vals[j-1], vals[j]
+		}
+	}
+}
+
+// siftDownCmpFuncKV implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDownCmpFuncKV[K, V any](data []K, lo, hi, first int, vals []V, cmp func(a, b K) int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (cmp(data[first+child], data[first+child+1]) < 0) {
+			child++
+		}
+		if !(cmp(data[first+root], data[first+child]) < 0) {
+			return
+		}
+		data[first+root], data[first+child], vals[first+root], vals[first+child] = data[first+child], data[first+root], vals[first+child], vals[first+root]
+		root = child
+	}
+}
+
+func heapSortCmpFuncKV[K, V any](data []K, a, b int, vals []V, cmp func(a, b K) int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDownCmpFuncKV(data, i, hi, first, vals, cmp)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i], vals[first], vals[first+i] = data[first+i], data[first], vals[first+i], vals[first]
+		siftDownCmpFuncKV(data, lo, i, first, vals, cmp)
+	}
+}
+
+// pdqsortCmpFuncKV sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsortCmpFuncKV[K, V any](data []K, a, b, limit int, vals []V, cmp func(a, b K) int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSortCmpFuncKV(data, a, b, vals, cmp)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSortCmpFuncKV(data, a, b, vals, cmp)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatternsCmpFuncKV(data, a, b, vals, cmp)
+			limit--
+		}
+
+		pivot, hint := choosePivotCmpFuncKV(data, a, b, vals, cmp)
+		if hint == decreasingHint {
+			reverseRangeCmpFuncKV(data, a, b, vals, cmp)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if partialInsertionSortCmpFuncKV(data, a, b, vals, cmp) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(cmp(data[a-1], data[pivot]) < 0) {
+			mid := partitionEqualCmpFuncKV(data, a, b, pivot, vals, cmp)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partitionCmpFuncKV(data, a, b, pivot, vals, cmp)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsortCmpFuncKV(data, a, mid, limit, vals, cmp)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsortCmpFuncKV(data, mid+1, b, limit, vals, cmp)
+			b = mid
+		}
+	}
+}
+
+// pdqselectCmpFuncKV places the element of rank k of data[a:b] at index k, with
+// the smaller elements before it and the larger ones after it.
+// It's pdqsortCmpFuncKV recursing only into the side that holds k.
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to
+// heapSelectCmpFuncKV or medianOfMediansCmpFuncKV.
+func pdqselectCmpFuncKV[K, V any](data []K, a, b, k, limit int, vals []V, cmp func(a, b K) int) {
+	if k == a { // Fast path; just find the minimum and place it in a
+		mn := a
+		for i := a + 1; i < b; i++ {
+			if cmp(data[i], data[mn]) < 0 {
+				mn = i
+			}
+		}
+		if mn != a {
+			data[a], data[mn], vals[a], vals[mn] = data[mn], data[a], vals[mn], vals[a]
+		}
+		return
+	}
+
+	if hi := b - 1; k == hi { // Fast path; just find the maximum and place it in b-1
+		mx := a
+		for i := a + 1; i < b; i++ {
+			if cmp(data[mx], data[i]) < 0 {
+				mx = i
+			}
+		}
+		if mx != hi {
+			data[hi], data[mx], vals[hi], vals[mx] = data[mx], data[hi], vals[mx], vals[hi]
+		}
+		return
+	}
+
+	const maxInsertion = 12
+
+	var (
+		lo             = a // Elements before lo are outside of the range being selected
+		wasBalanced    = true
+		wasPartitioned = true
+		budget         = 8 * (b - a) // Elements left to go through before falling back
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSortCmpFuncKV(data, a, b, vals, cmp)
+			return
+		}
+
+		// Fall back to heap select or median of medians if too many bad choices were
+		// made, or if going through the range over and over adds up to more than
+		// linear time, which bad choices that still count as balanced can do.
+		if limit == 0 || budget < 0 {
+			if k-a < heapSelectMax {
+				heapSelectCmpFuncKV(data, a, b, k-a, vals, cmp)
+			} else {
+				medianOfMediansCmpFuncKV(data, a, b, k, vals, cmp)
+			}
+			return
+		}
+		budget -= length
+
+		// Break patterns if the last partitioning was imbalanced
+		if !wasBalanced {
+			breakPatternsCmpFuncKV(data, a, b, vals, cmp)
+			limit--
+		}
+
+		pivot, hint := choosePivotCmpFuncKV(data, a, b, vals, cmp)
+		if hint == decreasingHint {
+			reverseRangeCmpFuncKV(data, a, b, vals, cmp)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// Check if the slice is likely already sorted
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if partialInsertionSortCmpFuncKV(data, a, b, vals, cmp) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > lo && !(cmp(data[a-1], data[pivot]) < 0) {
+			mid := partitionEqualCmpFuncKV(data, a, b, pivot, vals, cmp)
+			if k < mid {
+				return
+			}
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partitionCmpFuncKV(data, a, b, pivot, vals, cmp)
+		if k == mid {
+			return
+		}
+
+		wasPartitioned = alreadyPartitioned
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+
+		wasBalanced = min(leftLen, rightLen) >= balanceThreshold
+		if k < mid {
+			b = mid
+		} else { // k > mid
+			a = mid + 1
+		}
+	}
+}
+
+// heapSelectCmpFuncKV places the element of rank k of data[a:b], counted from a,
+// at index a+k, with the smaller elements before it, in O(n log k) time.
+func heapSelectCmpFuncKV[K, V any](data []K, a, b, k int, vals []V, cmp func(a, b K) int) {
+	n := b - a
+	hi := k + 1
+
+	// Build max-heap of first k elements
+	for i := k / 2; i >= 0; i-- {
+		siftDownCmpFuncKV(data, i, hi, a, vals, cmp)
+	}
+
+	// Process remaining elements
+	for i := hi; i < n; i++ {
+		j := a + i
+		if cmp(data[j], data[a]) < 0 {
+			data[a], data[j], vals[a], vals[j] = data[j], data[a], vals[j], vals[a]
+			siftDownCmpFuncKV(data, 0, hi, a, vals, cmp)
+		}
+	}
+
+	// Place the k-th element into its final place
+	data[a], data[a+k], vals[a], vals[a+k] = data[a+k], data[a], vals[a+k], vals[a]
+}
+
+// medianOfMediansCmpFuncKV places the element of rank k of data[a:b] at index k, with the
+// smaller elements before it and the larger ones after it, in O(n) time in the
+// worst case. It's the fallback of pdqselectCmpFuncKV for when too many bad pivots were
+// chosen, as heap select would take O(n log n) time for k far from a.
+//
+// The pivot is the median of the medians of groups of five elements, which
+// is selected recursively. At least 3/10 of the elements are no greater than it
+// and 3/10 no less, and elements equal to it are split off from the greater
+// ones, so each partition leaves at most 7/10 of the range to select from.
+func medianOfMediansCmpFuncKV[K, V any](data []K, a, b, k int, vals []V, cmp func(a, b K) int) {
+	const maxInsertion = 12
+
+	for b-a > maxInsertion {
+		// Move the median of each group of five elements to the front.
+		m := a
+		for i := a; i+5 <= b; i += 5 {
+			insertionSortCmpFuncKV(data, i, i+5, vals, cmp)
+			data[m], data[i+2], vals[m], vals[i+2] = data[i+2], data[m], vals[i+2], vals[m]
+			m++
+		}
+		pivot := a + (m-a)/2
+		medianOfMediansCmpFuncKV(data, a, m, pivot, vals, cmp)
+
+		mid, _ := partitionCmpFuncKV(data, a, b, pivot, vals, cmp)
+		if k < mid {
+			b = mid
+			continue
+		}
+		hi := partitionEqualCmpFuncKV(data, mid, b, mid, vals, cmp) // data[mid:hi] holds the elements equal to the pivot
+		if k < hi {
+			return
+		}
+		a = hi
+	}
+	insertionSortCmpFuncKV(data, a, b, vals, cmp)
+}
+
+// partitionCmpFuncKV does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partitionCmpFuncKV[K, V any](data []K, a, b, pivot int, vals []V, cmp func(a, b K) int) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot], vals[a], vals[pivot] = data[pivot], data[a], vals[pivot], vals[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (cmp(data[i], data[a]) < 0) {
+		i++
+	}
+	for i <= j && !(cmp(data[j], data[a]) < 0) {
+		j--
+	}
+	if i > j {
+		data[j], data[a], vals[j], vals[a] = data[a], data[j], vals[a], vals[j]
+		return j, true
+	}
+	data[i], data[j], vals[i], vals[j] = data[j], data[i], vals[j], vals[i]
+	i++
+	j--
+
+	for {
+		for i <= j && (cmp(data[i], data[a]) < 0) {
+			i++
+		}
+		for i <= j && !(cmp(data[j], data[a]) < 0) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j], vals[i], vals[j] = data[j], data[i], vals[j], vals[i]
+		i++
+		j--
+	}
+	data[j], data[a], vals[j], vals[a] = data[a], data[j], vals[a], vals[j]
+	return j, false
+}
+
+// partitionEqualCmpFuncKV partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqualCmpFuncKV[K, V any](data []K, a, b, pivot int, vals []V, cmp func(a, b K) int) (newpivot int) {
+	data[a], data[pivot], vals[a], vals[pivot] = data[pivot], data[a], vals[pivot], vals[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(cmp(data[a], data[i]) < 0) {
+			i++
+		}
+		for i <= j && (cmp(data[a], data[j]) < 0) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j], vals[i], vals[j] = data[j], data[i], vals[j], vals[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSortCmpFuncKV partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSortCmpFuncKV[K, V any](data []K, a, b int, vals []V, cmp func(a, b K) int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(cmp(data[i], data[i-1]) < 0) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1], vals[i], vals[i-1] = data[i-1], data[i], vals[i-1], vals[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j > a; j-- {
+				if !(cmp(data[j], data[j-1]) < 0) {
+					break
+				}
+				data[j], data[j-1], vals[j], vals[j-1] = data[j-1], data[j], vals[j-1], vals[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(cmp(data[j], data[j-1]) < 0) {
+					break
+				}
+				data[j], data[j-1], vals[j], vals[j-1] = data[j-1], data[j], vals[j-1], vals[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatternsCmpFuncKV scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatternsCmpFuncKV[K, V any](data []K, a, b int, vals []V, cmp func(a, b K) int) {
+	length := b - a
+	if length >= 8 {
+		random := xorshift(length)
+		modulus := nextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other], vals[idx], vals[a+other] = data[a+other], data[idx], vals[a+other], vals[idx]
+		}
+	}
+}
+
+// choosePivotCmpFuncKV chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivotCmpFuncKV[K, V any](data []K, a, b int, vals []V, cmp func(a, b K) int) (pivot int, hint sortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacentCmpFuncKV(data, i, &swaps, vals, cmp)
+			j = medianAdjacentCmpFuncKV(data, j, &swaps, vals, cmp)
+			k = medianAdjacentCmpFuncKV(data, k, &swaps, vals, cmp)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = medianCmpFuncKV(data, i, j, k, &swaps, vals, cmp)
+	}
+
+	switch swaps {
+	case 0:
+		return j, increasingHint
+	case maxSwaps:
+		return j, decreasingHint
+	default:
+		return j, unknownHint
+	}
+}
+
+// order2CmpFuncKV returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2CmpFuncKV[K, V any](data []K, a, b int, swaps *int, vals []V, cmp func(a, b K) int) (int, int) {
+	if cmp(data[b], data[a]) < 0 {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// medianCmpFuncKV returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func medianCmpFuncKV[K, V any](data []K, a, b, c int, swaps *int, vals []V, cmp func(a, b K) int) int {
+	a, b = order2CmpFuncKV(data, a, b, swaps, vals, cmp)
+	b, c = order2CmpFuncKV(data, b, c, swaps, vals, cmp)
+	a, b = order2CmpFuncKV(data, a, b, swaps, vals, cmp)
+	return b
+}
+
+// medianAdjacentCmpFuncKV finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacentCmpFuncKV[K, V any](data []K, a int, swaps *int, vals []V, cmp func(a, b K) int) int {
+	return medianCmpFuncKV(data, a-1, a, a+1, swaps, vals, cmp)
+}
+
+func reverseRangeCmpFuncKV[K, V any](data []K, a, b int, vals []V, cmp func(a, b K) int) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j], vals[i], vals[j] = data[j], data[i], vals[j], vals[i]
+		i++
+		j--
+	}
+}
+
+func swapRangeCmpFuncKV[K, V any](data []K, a, b, n int, vals []V, cmp func(a, b K) int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i], vals[a+i], vals[b+i] = data[b+i], data[a+i], vals[b+i], vals[a+i]
+	}
+}
+
+func stableCmpFuncKV[K, V any](data []K, n int, vals []V, cmp func(a, b K) int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSortCmpFuncKV(data, a, b, vals, cmp)
+		a = b
+		b += blockSize
+	}
+	insertionSortCmpFuncKV(data, a, n, vals, cmp)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMergeCmpFuncKV(data, a, a+blockSize, b, vals, cmp)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMergeCmpFuncKV(data, a, m, n, vals, cmp)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMergeCmpFuncKV merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMergeCmpFuncKV[K, V any](data []K, a, m, b int, vals []V, cmp func(a, b K) int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if cmp(data[h], data[a]) < 0 {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1], vals[k], vals[k+1] = data[k+1], data[k], vals[k+1], vals[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(cmp(data[m], data[h]) < 0) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1], vals[k], vals[k-1] = data[k-1], data[k], vals[k-1], vals[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(cmp(data[p-c], data[c]) < 0) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotateCmpFuncKV(data, start, m, end, vals, cmp)
+	}
+	if a < start && start < mid {
+		symMergeCmpFuncKV(data, a, start, mid, vals, cmp)
+	}
+	if mid < end && end < b {
+		symMergeCmpFuncKV(data, mid, end, b, vals, cmp)
+	}
+}
+
+// rotateCmpFuncKV rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotateCmpFuncKV[K, V any](data []K, a, m, b int, vals []V, cmp func(a, b K) int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRangeCmpFuncKV(data, m-i, m, j, vals, cmp)
+			i -= j
+		} else {
+			swapRangeCmpFuncKV(data, m-i, m+j-i, i, vals, cmp)
+			j -= i
+		}
+	}
+	// i == j
+	swapRangeCmpFuncKV(data, m-i, m, i, vals, cmp)
+}
@@ -0,0 +1,654 @@
+// Code generated by gen_sort_variants.go; DO NOT EDIT.
+
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pdqselect
+
+// insertionSortPtrCmpFunc sorts data[a:b] using insertion sort.
+func insertionSortPtrCmpFunc[E any](data []E, a, b int, cmp func(a, b *E) int) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && (cmp(&data[j], &data[j-1]) < 0); j-- {
+			data[j], data[j-1] = data[j-1], data[j]
+		}
+	}
+}
+
+// siftDownPtrCmpFunc implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDownPtrCmpFunc[E any](data []E, lo, hi, first int, cmp func(a, b *E) int) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && (cmp(&data[first+child], &data[first+child+1]) < 0) {
+			child++
+		}
+		if !(cmp(&data[first+root], &data[first+child]) < 0) {
+			return
+		}
+		data[first+root], data[first+child] = data[first+child], data[first+root]
+		root = child
+	}
+}
+
+func heapSortPtrCmpFunc[E any](data []E, a, b int, cmp func(a, b *E) int) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDownPtrCmpFunc(data, i, hi, first, cmp)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i] = data[first+i], data[first]
+		siftDownPtrCmpFunc(data, lo, i, first, cmp)
+	}
+}
+
+// pdqsortPtrCmpFunc sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsortPtrCmpFunc[E any](data []E, a, b, limit int, cmp func(a, b *E) int) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSortPtrCmpFunc(data, a, b, cmp)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSortPtrCmpFunc(data, a, b, cmp)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatternsPtrCmpFunc(data, a, b, cmp)
+			limit--
+		}
+
+		pivot, hint := choosePivotPtrCmpFunc(data, a, b, cmp)
+		if hint == decreasingHint {
+			reverseRangePtrCmpFunc(data, a, b, cmp)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if partialInsertionSortPtrCmpFunc(data, a, b, cmp) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !(cmp(&data[a-1], &data[pivot]) < 0) {
+			mid := partitionEqualPtrCmpFunc(data, a, b, pivot, cmp)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partitionPtrCmpFunc(data, a, b, pivot, cmp)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsortPtrCmpFunc(data, a, mid, limit, cmp)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsortPtrCmpFunc(data, mid+1, b, limit, cmp)
+			b = mid
+		}
+	}
+}
+
+// pdqselectPtrCmpFunc places the element of rank k of data[a:b] at index k, with
+// the smaller elements before it and the larger ones after it.
+// It's pdqsortPtrCmpFunc recursing only into the side that holds k.
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to
+// heapSelectPtrCmpFunc or medianOfMediansPtrCmpFunc.
+func pdqselectPtrCmpFunc[E any](data []E, a, b, k, limit int, cmp func(a, b *E) int) {
+	if k == a { // Fast path; just find the minimum and place it in a
+		mn := a
+		for i := a + 1; i < b; i++ {
+			if cmp(&data[i], &data[mn]) < 0 {
+				mn = i
+			}
+		}
+		if mn != a {
+			data[a], data[mn] = data[mn], data[a]
+		}
+		return
+	}
+
+	if hi := b - 1; k == hi { // Fast path; just find the maximum and place it in b-1
+		mx := a
+		for i := a + 1; i < b; i++ {
+			if cmp(&data[mx], &data[i]) < 0 {
+				mx = i
+			}
+		}
+		if mx != hi {
+			data[hi], data[mx] = data[mx], data[hi]
+		}
+		return
+	}
+
+	const maxInsertion = 12
+
+	var (
+		lo             = a // Elements before lo are outside of the range being selected
+		wasBalanced    = true
+		wasPartitioned = true
+		budget         = 8 * (b - a) // Elements left to go through before falling back
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSortPtrCmpFunc(data, a, b, cmp)
+			return
+		}
+
+		// Fall back to heap select or median of medians if too many bad choices were
+		// made, or if going through the range over and over adds up to more than
+		// linear time, which bad choices that still count as balanced can do.
+		if limit == 0 || budget < 0 {
+			if k-a < heapSelectMax {
+				heapSelectPtrCmpFunc(data, a, b, k-a, cmp)
+			} else {
+				medianOfMediansPtrCmpFunc(data, a, b, k, cmp)
+			}
+			return
+		}
+		budget -= length
+
+		// Break patterns if the last partitioning was imbalanced
+		if !wasBalanced {
+			breakPatternsPtrCmpFunc(data, a, b, cmp)
+			limit--
+		}
+
+		pivot, hint := choosePivotPtrCmpFunc(data, a, b, cmp)
+		if hint == decreasingHint {
+			reverseRangePtrCmpFunc(data, a, b, cmp)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// Check if the slice is likely already sorted
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if partialInsertionSortPtrCmpFunc(data, a, b, cmp) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > lo && !(cmp(&data[a-1], &data[pivot]) < 0) {
+			mid := partitionEqualPtrCmpFunc(data, a, b, pivot, cmp)
+			if k < mid {
+				return
+			}
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partitionPtrCmpFunc(data, a, b, pivot, cmp)
+		if k == mid {
+			return
+		}
+
+		wasPartitioned = alreadyPartitioned
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+
+		wasBalanced = min(leftLen, rightLen) >= balanceThreshold
+		if k < mid {
+			b = mid
+		} else { // k > mid
+			a = mid + 1
+		}
+	}
+}
+
+// heapSelectPtrCmpFunc places the element of rank k of data[a:b], counted from a,
+// at index a+k, with the smaller elements before it, in O(n log k) time.
+func heapSelectPtrCmpFunc[E any](data []E, a, b, k int, cmp func(a, b *E) int) {
+	n := b - a
+	hi := k + 1
+
+	// Build max-heap of first k elements
+	for i := k / 2; i >= 0; i-- {
+		siftDownPtrCmpFunc(data, i, hi, a, cmp)
+	}
+
+	// Process remaining elements
+	for i := hi; i < n; i++ {
+		j := a + i
+		if cmp(&data[j], &data[a]) < 0 {
+			data[a], data[j] = data[j], data[a]
+			siftDownPtrCmpFunc(data, 0, hi, a, cmp)
+		}
+	}
+
+	// Place the k-th element into its final place
+	data[a], data[a+k] = data[a+k], data[a]
+}
+
+// medianOfMediansPtrCmpFunc places the element of rank k of data[a:b] at index k, with the
+// smaller elements before it and the larger ones after it, in O(n) time in the
+// worst case. It's the fallback of pdqselectPtrCmpFunc for when too many bad pivots were
+// chosen, as heap select would take O(n log n) time for k far from a.
+//
+// The pivot is the median of the medians of groups of five elements, which
+// is selected recursively. At least 3/10 of the elements are no greater than it
+// and 3/10 no less, and elements equal to it are split off from the greater
+// ones, so each partition leaves at most 7/10 of the range to select from.
+func medianOfMediansPtrCmpFunc[E any](data []E, a, b, k int, cmp func(a, b *E) int) {
+	const maxInsertion = 12
+
+	for b-a > maxInsertion {
+		// Move the median of each group of five elements to the front.
+		m := a
+		for i := a; i+5 <= b; i += 5 {
+			insertionSortPtrCmpFunc(data, i, i+5, cmp)
+			data[m], data[i+2] = data[i+2], data[m]
+			m++
+		}
+		pivot := a + (m-a)/2
+		medianOfMediansPtrCmpFunc(data, a, m, pivot, cmp)
+
+		mid, _ := partitionPtrCmpFunc(data, a, b, pivot, cmp)
+		if k < mid {
+			b = mid
+			continue
+		}
+		hi := partitionEqualPtrCmpFunc(data, mid, b, mid, cmp) // data[mid:hi] holds the elements equal to the pivot
+		if k < hi {
+			return
+		}
+		a = hi
+	}
+	insertionSortPtrCmpFunc(data, a, b, cmp)
+}
+
+// partitionPtrCmpFunc does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partitionPtrCmpFunc[E any](data []E, a, b, pivot int, cmp func(a, b *E) int) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && (cmp(&data[i], &data[a]) < 0) {
+		i++
+	}
+	for i <= j && !(cmp(&data[j], &data[a]) < 0) {
+		j--
+	}
+	if i > j {
+		data[j], data[a] = data[a], data[j]
+		return j, true
+	}
+	data[i], data[j] = data[j], data[i]
+	i++
+	j--
+
+	for {
+		for i <= j && (cmp(&data[i], &data[a]) < 0) {
+			i++
+		}
+		for i <= j && !(cmp(&data[j], &data[a]) < 0) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	data[j], data[a] = data[a], data[j]
+	return j, false
+}
+
+// partitionEqualPtrCmpFunc partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqualPtrCmpFunc[E any](data []E, a, b, pivot int, cmp func(a, b *E) int) (newpivot int) {
+	data[a], data[pivot] = data[pivot], data[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !(cmp(&data[a], &data[i]) < 0) {
+			i++
+		}
+		for i <= j && (cmp(&data[a], &data[j]) < 0) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSortPtrCmpFunc partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSortPtrCmpFunc[E any](data []E, a, b int, cmp func(a, b *E) int) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !(cmp(&data[i], &data[i-1]) < 0) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1] = data[i-1], data[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j > a; j-- {
+				if !(cmp(&data[j], &data[j-1]) < 0) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !(cmp(&data[j], &data[j-1]) < 0) {
+					break
+				}
+				data[j], data[j-1] = data[j-1], data[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatternsPtrCmpFunc scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatternsPtrCmpFunc[E any](data []E, a, b int, cmp func(a, b *E) int) {
+	length := b - a
+	if length >= 8 {
+		random := xorshift(length)
+		modulus := nextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other] = data[a+other], data[idx]
+		}
+	}
+}
+
+// choosePivotPtrCmpFunc chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivotPtrCmpFunc[E any](data []E, a, b int, cmp func(a, b *E) int) (pivot int, hint sortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacentPtrCmpFunc(data, i, &swaps, cmp)
+			j = medianAdjacentPtrCmpFunc(data, j, &swaps, cmp)
+			k = medianAdjacentPtrCmpFunc(data, k, &swaps, cmp)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = medianPtrCmpFunc(data, i, j, k, &swaps, cmp)
+	}
+
+	switch swaps {
+	case 0:
+		return j, increasingHint
+	case maxSwaps:
+		return j, decreasingHint
+	default:
+		return j, unknownHint
+	}
+}
+
+// order2PtrCmpFunc returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2PtrCmpFunc[E any](data []E, a, b int, swaps *int, cmp func(a, b *E) int) (int, int) {
+	if cmp(&data[b], &data[a]) < 0 {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// medianPtrCmpFunc returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func medianPtrCmpFunc[E any](data []E, a, b, c int, swaps *int, cmp func(a, b *E) int) int {
+	a, b = order2PtrCmpFunc(data, a, b, swaps, cmp)
+	b, c = order2PtrCmpFunc(data, b, c, swaps, cmp)
+	a, b = order2PtrCmpFunc(data, a, b, swaps, cmp)
+	return b
+}
+
+// medianAdjacentPtrCmpFunc finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacentPtrCmpFunc[E any](data []E, a int, swaps *int, cmp func(a, b *E) int) int {
+	return medianPtrCmpFunc(data, a-1, a, a+1, swaps, cmp)
+}
+
+func reverseRangePtrCmpFunc[E any](data []E, a, b int, cmp func(a, b *E) int) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j] = data[j], data[i]
+		i++
+		j--
+	}
+}
+
+func swapRangePtrCmpFunc[E any](data []E, a, b, n int, cmp func(a, b *E) int) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i] = data[b+i], data[a+i]
+	}
+}
+
+func stablePtrCmpFunc[E any](data []E, n int, cmp func(a, b *E) int) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSortPtrCmpFunc(data, a, b, cmp)
+		a = b
+		b += blockSize
+	}
+	insertionSortPtrCmpFunc(data, a, n, cmp)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMergePtrCmpFunc(data, a, a+blockSize, b, cmp)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMergePtrCmpFunc(data, a, m, n, cmp)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMergePtrCmpFunc merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMergePtrCmpFunc[E any](data []E, a, m, b int, cmp func(a, b *E) int) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if cmp(&data[h], &data[a]) < 0 {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1] = data[k+1], data[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !(cmp(&data[m], &data[h]) < 0) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1] = data[k-1], data[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !(cmp(&data[p-c], &data[c]) < 0) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotatePtrCmpFunc(data, start, m, end, cmp)
+	}
+	if a < start && start < mid {
+		symMergePtrCmpFunc(data, a, start, mid, cmp)
+	}
+	if mid < end && end < b {
+		symMergePtrCmpFunc(data, mid, end, b, cmp)
+	}
+}
+
+// rotatePtrCmpFunc rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotatePtrCmpFunc[E any](data []E, a, m, b int, cmp func(a, b *E) int) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRangePtrCmpFunc(data, m-i, m, j, cmp)
+			i -= j
+		} else {
+			swapRangePtrCmpFunc(data, m-i, m+j-i, i, cmp)
+			j -= i
+		}
+	}
+	// i == j
+	swapRangePtrCmpFunc(data, m-i, m, i, cmp)
+}
@@ -0,0 +1,656 @@
+// Code generated by gen_sort_variants.go; DO NOT EDIT.
+
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pdqselect
+
+import "cmp"
+
+// insertionSortOrderedKV sorts data[a:b] using insertion sort.
+func insertionSortOrderedKV[K cmp.Ordered, V any](data []K, a, b int, vals []V) {
+	for i := a + 1; i < b; i++ {
+		for j := i; j > a && cmp.Less(data[j], data[j-1]); j-- {
+			data[j], data[j-1], vals[j], vals[j-1] = data[j-1], data[j], vals[j-1], vals[j]
+		}
+	}
+}
+
+// siftDownOrderedKV implements the heap property on data[lo:hi].
+// first is an offset into the array where the root of the heap lies.
+func siftDownOrderedKV[K cmp.Ordered, V any](data []K, lo, hi, first int, vals []V) {
+	root := lo
+	for {
+		child := 2*root + 1
+		if child >= hi {
+			break
+		}
+		if child+1 < hi && cmp.Less(data[first+child], data[first+child+1]) {
+			child++
+		}
+		if !cmp.Less(data[first+root], data[first+child]) {
+			return
+		}
+		data[first+root], data[first+child], vals[first+root], vals[first+child] = data[first+child], data[first+root], vals[first+child], vals[first+root]
+		root = child
+	}
+}
+
+func heapSortOrderedKV[K cmp.Ordered, V any](data []K, a, b int, vals []V) {
+	first := a
+	lo := 0
+	hi := b - a
+
+	// Build heap with greatest element at top.
+	for i := (hi - 1) / 2; i >= 0; i-- {
+		siftDownOrderedKV(data, i, hi, first, vals)
+	}
+
+	// Pop elements, largest first, into end of data.
+	for i := hi - 1; i >= 0; i-- {
+		data[first], data[first+i], vals[first], vals[first+i] = data[first+i], data[first], vals[first+i], vals[first]
+		siftDownOrderedKV(data, lo, i, first, vals)
+	}
+}
+
+// pdqsortOrderedKV sorts data[a:b].
+// The algorithm based on pattern-defeating quicksort(pdqsort), but without the optimizations from BlockQuicksort.
+// pdqsort paper: https://arxiv.org/pdf/2106.05123.pdf
+// C++ implementation: https://github.com/orlp/pdqsort
+// Rust implementation: https://docs.rs/pdqsort/latest/pdqsort/
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to heapsort.
+func pdqsortOrderedKV[K cmp.Ordered, V any](data []K, a, b, limit int, vals []V) {
+	const maxInsertion = 12
+
+	var (
+		wasBalanced    = true // whether the last partitioning was reasonably balanced
+		wasPartitioned = true // whether the slice was already partitioned
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSortOrderedKV(data, a, b, vals)
+			return
+		}
+
+		// Fall back to heapsort if too many bad choices were made.
+		if limit == 0 {
+			heapSortOrderedKV(data, a, b, vals)
+			return
+		}
+
+		// If the last partitioning was imbalanced, we need to breaking patterns.
+		if !wasBalanced {
+			breakPatternsOrderedKV(data, a, b, vals)
+			limit--
+		}
+
+		pivot, hint := choosePivotOrderedKV(data, a, b, vals)
+		if hint == decreasingHint {
+			reverseRangeOrderedKV(data, a, b, vals)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// The slice is likely already sorted.
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if partialInsertionSortOrderedKV(data, a, b, vals) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > 0 && !cmp.Less(data[a-1], data[pivot]) {
+			mid := partitionEqualOrderedKV(data, a, b, pivot, vals)
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partitionOrderedKV(data, a, b, pivot, vals)
+		wasPartitioned = alreadyPartitioned
+
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+		if leftLen < rightLen {
+			wasBalanced = leftLen >= balanceThreshold
+			pdqsortOrderedKV(data, a, mid, limit, vals)
+			a = mid + 1
+		} else {
+			wasBalanced = rightLen >= balanceThreshold
+			pdqsortOrderedKV(data, mid+1, b, limit, vals)
+			b = mid
+		}
+	}
+}
+
+// pdqselectOrderedKV places the element of rank k of data[a:b] at index k, with
+// the smaller elements before it and the larger ones after it.
+// It's pdqsortOrderedKV recursing only into the side that holds k.
+// limit is the number of allowed bad (very unbalanced) pivots before falling back to
+// heapSelectOrderedKV or medianOfMediansOrderedKV.
+func pdqselectOrderedKV[K cmp.Ordered, V any](data []K, a, b, k, limit int, vals []V) {
+	if k == a { // Fast path; just find the minimum and place it in a
+		mn := a
+		for i := a + 1; i < b; i++ {
+			if cmp.Less(data[i], data[mn]) {
+				mn = i
+			}
+		}
+		if mn != a {
+			data[a], data[mn], vals[a], vals[mn] = data[mn], data[a], vals[mn], vals[a]
+		}
+		return
+	}
+
+	if hi := b - 1; k == hi { // Fast path; just find the maximum and place it in b-1
+		mx := a
+		for i := a + 1; i < b; i++ {
+			if cmp.Less(data[mx], data[i]) {
+				mx = i
+			}
+		}
+		if mx != hi {
+			data[hi], data[mx], vals[hi], vals[mx] = data[mx], data[hi], vals[mx], vals[hi]
+		}
+		return
+	}
+
+	const maxInsertion = 12
+
+	var (
+		lo             = a // Elements before lo are outside of the range being selected
+		wasBalanced    = true
+		wasPartitioned = true
+		budget         = 8 * (b - a) // Elements left to go through before falling back
+	)
+
+	for {
+		length := b - a
+
+		if length <= maxInsertion {
+			insertionSortOrderedKV(data, a, b, vals)
+			return
+		}
+
+		// Fall back to heap select or median of medians if too many bad choices were
+		// made, or if going through the range over and over adds up to more than
+		// linear time, which bad choices that still count as balanced can do.
+		if limit == 0 || budget < 0 {
+			if k-a < heapSelectMax {
+				heapSelectOrderedKV(data, a, b, k-a, vals)
+			} else {
+				medianOfMediansOrderedKV(data, a, b, k, vals)
+			}
+			return
+		}
+		budget -= length
+
+		// Break patterns if the last partitioning was imbalanced
+		if !wasBalanced {
+			breakPatternsOrderedKV(data, a, b, vals)
+			limit--
+		}
+
+		pivot, hint := choosePivotOrderedKV(data, a, b, vals)
+		if hint == decreasingHint {
+			reverseRangeOrderedKV(data, a, b, vals)
+			// The chosen pivot was pivot-a elements after the start of the array.
+			// After reversing it is pivot-a elements before the end of the array.
+			// The idea came from Rust's implementation.
+			pivot = (b - 1) - (pivot - a)
+			hint = increasingHint
+		}
+
+		// Check if the slice is likely already sorted
+		if wasBalanced && wasPartitioned && hint == increasingHint {
+			if partialInsertionSortOrderedKV(data, a, b, vals) {
+				return
+			}
+		}
+
+		// Probably the slice contains many duplicate elements, partition the slice into
+		// elements equal to and elements greater than the pivot.
+		if a > lo && !cmp.Less(data[a-1], data[pivot]) {
+			mid := partitionEqualOrderedKV(data, a, b, pivot, vals)
+			if k < mid {
+				return
+			}
+			a = mid
+			continue
+		}
+
+		mid, alreadyPartitioned := partitionOrderedKV(data, a, b, pivot, vals)
+		if k == mid {
+			return
+		}
+
+		wasPartitioned = alreadyPartitioned
+		leftLen, rightLen := mid-a, b-mid
+		balanceThreshold := length / 8
+
+		wasBalanced = min(leftLen, rightLen) >= balanceThreshold
+		if k < mid {
+			b = mid
+		} else { // k > mid
+			a = mid + 1
+		}
+	}
+}
+
+// heapSelectOrderedKV places the element of rank k of data[a:b], counted from a,
+// at index a+k, with the smaller elements before it, in O(n log k) time.
+func heapSelectOrderedKV[K cmp.Ordered, V any](data []K, a, b, k int, vals []V) {
+	n := b - a
+	hi := k + 1
+
+	// Build max-heap of first k elements
+	for i := k / 2; i >= 0; i-- {
+		siftDownOrderedKV(data, i, hi, a, vals)
+	}
+
+	// Process remaining elements
+	for i := hi; i < n; i++ {
+		j := a + i
+		if cmp.Less(data[j], data[a]) {
+			data[a], data[j], vals[a], vals[j] = data[j], data[a], vals[j], vals[a]
+			siftDownOrderedKV(data, 0, hi, a, vals)
+		}
+	}
+
+	// Place the k-th element into its final place
+	data[a], data[a+k], vals[a], vals[a+k] = data[a+k], data[a], vals[a+k], vals[a]
+}
+
+// medianOfMediansOrderedKV places the element of rank k of data[a:b] at index k, with the
+// smaller elements before it and the larger ones after it, in O(n) time in the
+// worst case. It's the fallback of pdqselectOrderedKV for when too many bad pivots were
+// chosen, as heap select would take O(n log n) time for k far from a.
+//
+// The pivot is the median of the medians of groups of five elements, which
+// is selected recursively. At least 3/10 of the elements are no greater than it
+// and 3/10 no less, and elements equal to it are split off from the greater
+// ones, so each partition leaves at most 7/10 of the range to select from.
+func medianOfMediansOrderedKV[K cmp.Ordered, V any](data []K, a, b, k int, vals []V) {
+	const maxInsertion = 12
+
+	for b-a > maxInsertion {
+		// Move the median of each group of five elements to the front.
+		m := a
+		for i := a; i+5 <= b; i += 5 {
+			insertionSortOrderedKV(data, i, i+5, vals)
+			data[m], data[i+2], vals[m], vals[i+2] = data[i+2], data[m], vals[i+2], vals[m]
+			m++
+		}
+		pivot := a + (m-a)/2
+		medianOfMediansOrderedKV(data, a, m, pivot, vals)
+
+		mid, _ := partitionOrderedKV(data, a, b, pivot, vals)
+		if k < mid {
+			b = mid
+			continue
+		}
+		hi := partitionEqualOrderedKV(data, mid, b, mid, vals) // data[mid:hi] holds the elements equal to the pivot
+		if k < hi {
+			return
+		}
+		a = hi
+	}
+	insertionSortOrderedKV(data, a, b, vals)
+}
+
+// partitionOrderedKV does one quicksort partition.
+// Let p = data[pivot]
+// Moves elements in data[a:b] around, so that data[i]<p and data[j]>=p for i<newpivot and j>newpivot.
+// On return, data[newpivot] = p
+func partitionOrderedKV[K cmp.Ordered, V any](data []K, a, b, pivot int, vals []V) (newpivot int, alreadyPartitioned bool) {
+	data[a], data[pivot], vals[a], vals[pivot] = data[pivot], data[a], vals[pivot], vals[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for i <= j && cmp.Less(data[i], data[a]) {
+		i++
+	}
+	for i <= j && !cmp.Less(data[j], data[a]) {
+		j--
+	}
+	if i > j {
+		data[j], data[a], vals[j], vals[a] = data[a], data[j], vals[a], vals[j]
+		return j, true
+	}
+	data[i], data[j], vals[i], vals[j] = data[j], data[i], vals[j], vals[i]
+	i++
+	j--
+
+	for {
+		for i <= j && cmp.Less(data[i], data[a]) {
+			i++
+		}
+		for i <= j && !cmp.Less(data[j], data[a]) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j], vals[i], vals[j] = data[j], data[i], vals[j], vals[i]
+		i++
+		j--
+	}
+	data[j], data[a], vals[j], vals[a] = data[a], data[j], vals[a], vals[j]
+	return j, false
+}
+
+// partitionEqualOrderedKV partitions data[a:b] into elements equal to data[pivot] followed by elements greater than data[pivot].
+// It assumed that data[a:b] does not contain elements smaller than the data[pivot].
+func partitionEqualOrderedKV[K cmp.Ordered, V any](data []K, a, b, pivot int, vals []V) (newpivot int) {
+	data[a], data[pivot], vals[a], vals[pivot] = data[pivot], data[a], vals[pivot], vals[a]
+	i, j := a+1, b-1 // i and j are inclusive of the elements remaining to be partitioned
+
+	for {
+		for i <= j && !cmp.Less(data[a], data[i]) {
+			i++
+		}
+		for i <= j && cmp.Less(data[a], data[j]) {
+			j--
+		}
+		if i > j {
+			break
+		}
+		data[i], data[j], vals[i], vals[j] = data[j], data[i], vals[j], vals[i]
+		i++
+		j--
+	}
+	return i
+}
+
+// partialInsertionSortOrderedKV partially sorts a slice, returns true if the slice is sorted at the end.
+func partialInsertionSortOrderedKV[K cmp.Ordered, V any](data []K, a, b int, vals []V) bool {
+	const (
+		maxSteps         = 5  // maximum number of adjacent out-of-order pairs that will get shifted
+		shortestShifting = 50 // don't shift any elements on short arrays
+	)
+	i := a + 1
+	for j := 0; j < maxSteps; j++ {
+		for i < b && !cmp.Less(data[i], data[i-1]) {
+			i++
+		}
+
+		if i == b {
+			return true
+		}
+
+		if b-a < shortestShifting {
+			return false
+		}
+
+		data[i], data[i-1], vals[i], vals[i-1] = data[i-1], data[i], vals[i-1], vals[i]
+
+		// Shift the smaller one to the left.
+		if i-a >= 2 {
+			for j := i - 1; j > a; j-- {
+				if !cmp.Less(data[j], data[j-1]) {
+					break
+				}
+				data[j], data[j-1], vals[j], vals[j-1] = data[j-1], data[j], vals[j-1], vals[j]
+			}
+		}
+		// Shift the greater one to the right.
+		if b-i >= 2 {
+			for j := i + 1; j < b; j++ {
+				if !cmp.Less(data[j], data[j-1]) {
+					break
+				}
+				data[j], data[j-1], vals[j], vals[j-1] = data[j-1], data[j], vals[j-1], vals[j]
+			}
+		}
+	}
+	return false
+}
+
+// breakPatternsOrderedKV scatters some elements around in an attempt to break some patterns
+// that might cause imbalanced partitions in quicksort.
+func breakPatternsOrderedKV[K cmp.Ordered, V any](data []K, a, b int, vals []V) {
+	length := b - a
+	if length >= 8 {
+		random := xorshift(length)
+		modulus := nextPowerOfTwo(length)
+
+		for idx := a + (length/4)*2 - 1; idx <= a+(length/4)*2+1; idx++ {
+			other := int(uint(random.Next()) & (modulus - 1))
+			if other >= length {
+				other -= length
+			}
+			data[idx], data[a+other], vals[idx], vals[a+other] = data[a+other], data[idx], vals[a+other], vals[idx]
+		}
+	}
+}
+
+// choosePivotOrderedKV chooses a pivot in data[a:b].
+//
+// [0,8): chooses a static pivot.
+// [8,shortestNinther): uses the simple median-of-three method.
+// [shortestNinther,∞): uses the Tukey ninther method.
+func choosePivotOrderedKV[K cmp.Ordered, V any](data []K, a, b int, vals []V) (pivot int, hint sortedHint) {
+	const (
+		shortestNinther = 50
+		maxSwaps        = 4 * 3
+	)
+
+	l := b - a
+
+	var (
+		swaps int
+		i     = a + l/4*1
+		j     = a + l/4*2
+		k     = a + l/4*3
+	)
+
+	if l >= 8 {
+		if l >= shortestNinther {
+			// Tukey ninther method, the idea came from Rust's implementation.
+			i = medianAdjacentOrderedKV(data, i, &swaps, vals)
+			j = medianAdjacentOrderedKV(data, j, &swaps, vals)
+			k = medianAdjacentOrderedKV(data, k, &swaps, vals)
+		}
+		// Find the median among i, j, k and stores it into j.
+		j = medianOrderedKV(data, i, j, k, &swaps, vals)
+	}
+
+	switch swaps {
+	case 0:
+		return j, increasingHint
+	case maxSwaps:
+		return j, decreasingHint
+	default:
+		return j, unknownHint
+	}
+}
+
+// order2OrderedKV returns x,y where data[x] <= data[y], where x,y=a,b or x,y=b,a.
+func order2OrderedKV[K cmp.Ordered, V any](data []K, a, b int, swaps *int, vals []V) (int, int) {
+	if cmp.Less(data[b], data[a]) {
+		*swaps++
+		return b, a
+	}
+	return a, b
+}
+
+// medianOrderedKV returns x where data[x] is the median of data[a],data[b],data[c], where x is a, b, or c.
+func medianOrderedKV[K cmp.Ordered, V any](data []K, a, b, c int, swaps *int, vals []V) int {
+	a, b = order2OrderedKV(data, a, b, swaps, vals)
+	b, c = order2OrderedKV(data, b, c, swaps, vals)
+	a, b = order2OrderedKV(data, a, b, swaps, vals)
+	return b
+}
+
+// medianAdjacentOrderedKV finds the median of data[a - 1], data[a], data[a + 1] and stores the index into a.
+func medianAdjacentOrderedKV[K cmp.Ordered, V any](data []K, a int, swaps *int, vals []V) int {
+	return medianOrderedKV(data, a-1, a, a+1, swaps, vals)
+}
+
+func reverseRangeOrderedKV[K cmp.Ordered, V any](data []K, a, b int, vals []V) {
+	i := a
+	j := b - 1
+	for i < j {
+		data[i], data[j], vals[i], vals[j] = data[j], data[i], vals[j], vals[i]
+		i++
+		j--
+	}
+}
+
+func swapRangeOrderedKV[K cmp.Ordered, V any](data []K, a, b, n int, vals []V) {
+	for i := 0; i < n; i++ {
+		data[a+i], data[b+i], vals[a+i], vals[b+i] = data[b+i], data[a+i], vals[b+i], vals[a+i]
+	}
+}
+
+func stableOrderedKV[K cmp.Ordered, V any](data []K, n int, vals []V) {
+	blockSize := 20 // must be > 0
+	a, b := 0, blockSize
+	for b <= n {
+		insertionSortOrderedKV(data, a, b, vals)
+		a = b
+		b += blockSize
+	}
+	insertionSortOrderedKV(data, a, n, vals)
+
+	for blockSize < n {
+		a, b = 0, 2*blockSize
+		for b <= n {
+			symMergeOrderedKV(data, a, a+blockSize, b, vals)
+			a = b
+			b += 2 * blockSize
+		}
+		if m := a + blockSize; m < n {
+			symMergeOrderedKV(data, a, m, n, vals)
+		}
+		blockSize *= 2
+	}
+}
+
+// symMergeOrderedKV merges the two sorted subsequences data[a:m] and data[m:b] using
+// the SymMerge algorithm from Pok-Son Kim and Arne Kutzner, "Stable Minimum
+// Storage Merging by Symmetric Comparisons", in Susanne Albers and Tomasz
+// Radzik, editors, Algorithms - ESA 2004, volume 3221 of Lecture Notes in
+// Computer Science, pages 714-723. Springer, 2004.
+//
+// Let M = m-a and N = b-n. Wolog M < N.
+// The recursion depth is bound by ceil(log(N+M)).
+// The algorithm needs O(M*log(N/M + 1)) calls to data.Less.
+// The algorithm needs O((M+N)*log(M)) calls to data.Swap.
+//
+// The paper gives O((M+N)*log(M)) as the number of assignments assuming a
+// rotation algorithm which uses O(M+N+gcd(M+N)) assignments. The argumentation
+// in the paper carries through for Swap operations, especially as the block
+// swapping rotate uses only O(M+N) Swaps.
+//
+// symMerge assumes non-degenerate arguments: a < m && m < b.
+// Having the caller check this condition eliminates many leaf recursion calls,
+// which improves performance.
+func symMergeOrderedKV[K cmp.Ordered, V any](data []K, a, m, b int, vals []V) {
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[a] into data[m:b]
+	// if data[a:m] only contains one element.
+	if m-a == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] >= data[a] for m <= i < b.
+		// Exit the search loop with i == b in case no such index exists.
+		i := m
+		j := b
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if cmp.Less(data[h], data[a]) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[a] reaches the position before i.
+		for k := a; k < i-1; k++ {
+			data[k], data[k+1], vals[k], vals[k+1] = data[k+1], data[k], vals[k+1], vals[k]
+		}
+		return
+	}
+
+	// Avoid unnecessary recursions of symMerge
+	// by direct insertion of data[m] into data[a:m]
+	// if data[m:b] only contains one element.
+	if b-m == 1 {
+		// Use binary search to find the lowest index i
+		// such that data[i] > data[m] for a <= i < m.
+		// Exit the search loop with i == m in case no such index exists.
+		i := a
+		j := m
+		for i < j {
+			h := int(uint(i+j) >> 1)
+			if !cmp.Less(data[m], data[h]) {
+				i = h + 1
+			} else {
+				j = h
+			}
+		}
+		// Swap values until data[m] reaches the position i.
+		for k := m; k > i; k-- {
+			data[k], data[k-1], vals[k], vals[k-1] = data[k-1], data[k], vals[k-1], vals[k]
+		}
+		return
+	}
+
+	mid := int(uint(a+b) >> 1)
+	n := mid + m
+	var start, r int
+	if m > mid {
+		start = n - b
+		r = mid
+	} else {
+		start = a
+		r = m
+	}
+	p := n - 1
+
+	for start < r {
+		c := int(uint(start+r) >> 1)
+		if !cmp.Less(data[p-c], data[c]) {
+			start = c + 1
+		} else {
+			r = c
+		}
+	}
+
+	end := n - start
+	if start < m && m < end {
+		rotateOrderedKV(data, start, m, end, vals)
+	}
+	if a < start && start < mid {
+		symMergeOrderedKV(data, a, start, mid, vals)
+	}
+	if mid < end && end < b {
+		symMergeOrderedKV(data, mid, end, b, vals)
+	}
+}
+
+// rotateOrderedKV rotates two consecutive blocks u = data[a:m] and v = data[m:b] in data:
+// Data of the form 'x u v y' is changed to 'x v u y'.
+// rotate performs at most b-a many calls to data.Swap,
+// and it assumes non-degenerate arguments: a < m && m < b.
+func rotateOrderedKV[K cmp.Ordered, V any](data []K, a, m, b int, vals []V) {
+	i := m - a
+	j := b - m
+
+	for i != j {
+		if i > j {
+			swapRangeOrderedKV(data, m-i, m, j, vals)
+			i -= j
+		} else {
+			swapRangeOrderedKV(data, m-i, m+j-i, i, vals)
+			j -= i
+		}
+	}
+	// i == j
+	swapRangeOrderedKV(data, m-i, m, i, vals)
+}